@@ -0,0 +1,203 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package blobstore stores large values ("blobs") in YottaDB, addressed by the SHA-256 hash of their content.
+
+Values larger than a database record are split into chunks. Storing the same content twice stores it once and counts a
+reference for each store, so content shared by many records (attachments, images, documents) takes space once. Deleting a
+blob drops one reference, and Collect() frees the space of blobs with no references left.
+
+A blob is stored in ^blobs(hash,...) nodes (or in another global variable of the application's choosing):
+
+	^blobs(hash,"refs")       number of references
+	^blobs(hash,"size")       length of the blob in bytes
+	^blobs(hash,"chunk",n)    the nth chunk of the blob, numbered from 1
+
+For example:
+
+	store := blobstore.New()
+	hash, err := store.Put(yottadb.NOTTP, nil, content)
+	...
+	content, err = store.Get(yottadb.NOTTP, nil, hash)
+*/
+package blobstore
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+)
+
+// DefaultGlobal is the global variable New() stores blobs in
+const DefaultGlobal string = "^blobs"
+
+// DefaultChunkSize is the largest chunk New() stores in a node, chosen to fit the default maximum record size of a region
+const DefaultChunkSize int = 4000
+
+// ErrNotFound is returned when no blob with the given hash is stored (or all references to it have been deleted)
+var ErrNotFound = errors.New("blobstore: blob not found")
+
+// Store is a store of blobs kept in Global(hash,...) nodes
+type Store struct {
+	Global    string // Global (or local) variable name holding blobs, e.g. "^blobs"
+	ChunkSize int    // Largest number of bytes of a blob stored in one node - at most the maximum record size of the region
+}
+
+// New is a function to return a store kept in DefaultGlobal with chunks of DefaultChunkSize bytes
+func New() *Store {
+	return &Store{Global: DefaultGlobal, ChunkSize: DefaultChunkSize}
+}
+
+// Hash is a function to return the hash that addresses the given content in a store - the SHA-256 hash of the content in
+// hexadecimal
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Put is a method to store the given content and return its hash. If the content is already stored, another reference to
+// it is counted instead. The content is stored in a single transaction, which may be called within another transaction.
+func (store *Store) Put(tptoken uint64, errstr *yottadb.BufferT, content []byte) (string, error) {
+	if 0 >= store.ChunkSize {
+		panic("blobstore: ChunkSize must be positive")
+	}
+	hash := Hash(content)
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		refs, err := yottadb.IncrE(tptoken, errstr, "1", store.Global, []string{hash, "refs"})
+		if nil != err || "1" != refs {
+			return struct{}{}, err // Chunks are only stored with the first reference
+		}
+		err = yottadb.SetValE(tptoken, errstr, strconv.Itoa(len(content)), store.Global, []string{hash, "size"})
+		if nil != err {
+			return struct{}{}, err
+		}
+		for n, start := 1, 0; start < len(content); n, start = n+1, start+store.ChunkSize {
+			end := start + store.ChunkSize
+			if end > len(content) {
+				end = len(content)
+			}
+			err = yottadb.SetValE(tptoken, errstr, string(content[start:end]), store.Global,
+				[]string{hash, "chunk", strconv.Itoa(n)})
+			if nil != err {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	}, "", []string{})
+	if nil != err {
+		return "", err
+	}
+	return hash, nil
+}
+
+// Get is a method to return the content of the blob with the given hash, or ErrNotFound if it is not stored. The chunks are
+// read in a single transaction, so the content is consistent with a concurrent Put() or Collect().
+func (store *Store) Get(tptoken uint64, errstr *yottadb.BufferT, hash string) ([]byte, error) {
+	return yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) ([]byte, error) {
+		refs, err := store.Refs(tptoken, errstr, hash)
+		if nil != err {
+			return nil, err
+		}
+		if 0 >= refs {
+			return nil, ErrNotFound
+		}
+		value, err := yottadb.ValE(tptoken, errstr, store.Global, []string{hash, "size"})
+		if nil != err {
+			return nil, err
+		}
+		size, err := strconv.Atoi(value)
+		if nil != err {
+			return nil, fmt.Errorf("blobstore: invalid size %q of blob %s", value, hash)
+		}
+		content := make([]byte, 0, size)
+		for n := 1; len(content) < size; n++ {
+			chunk, err := yottadb.ValE(tptoken, errstr, store.Global, []string{hash, "chunk", strconv.Itoa(n)})
+			if nil != err {
+				return nil, err
+			}
+			content = append(content, chunk...)
+		}
+		if len(content) != size {
+			return nil, fmt.Errorf("blobstore: blob %s has %d bytes instead of %d", hash, len(content), size)
+		}
+		return content, nil
+	}, "", []string{})
+}
+
+// Delete is a method to delete one reference to the blob with the given hash, or return ErrNotFound if it has none. The
+// space of a blob with no references left is freed by Collect().
+func (store *Store) Delete(tptoken uint64, errstr *yottadb.BufferT, hash string) error {
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		refs, err := store.Refs(tptoken, errstr, hash)
+		if nil != err {
+			return struct{}{}, err
+		}
+		if 0 >= refs {
+			return struct{}{}, ErrNotFound
+		}
+		_, err = yottadb.IncrE(tptoken, errstr, "-1", store.Global, []string{hash, "refs"})
+		return struct{}{}, err
+	}, "", []string{})
+	return err
+}
+
+// Refs is a method to return the number of references to the blob with the given hash - 0 if it is not stored.
+func (store *Store) Refs(tptoken uint64, errstr *yottadb.BufferT, hash string) (int, error) {
+	value, err := yottadb.ValE(tptoken, errstr, store.Global, []string{hash, "refs"})
+	if nil != err {
+		if yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	refs, err := strconv.Atoi(value)
+	if nil != err {
+		return 0, fmt.Errorf("blobstore: invalid reference count %q of blob %s", value, hash)
+	}
+	return refs, nil
+}
+
+// Collect is a method to free the space of all blobs with no references left, and return the number of blobs freed. Each
+// blob is freed in its own transaction, so Collect() can run while the store is in use.
+func (store *Store) Collect(tptoken uint64, errstr *yottadb.BufferT) (int, error) {
+	var freed int
+
+	subary := []string{""}
+	for {
+		hash, err := yottadb.SubNextE(tptoken, errstr, store.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				return freed, nil
+			}
+			return freed, err
+		}
+		subary[0] = hash
+		// Check the references again in the transaction, as a Put() may have added one since
+		deleted, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (bool, error) {
+			refs, err := store.Refs(tptoken, errstr, hash)
+			if nil != err || 0 < refs {
+				return false, err
+			}
+			return true, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, store.Global, []string{hash})
+		}, "", []string{})
+		if nil != err {
+			return freed, err
+		}
+		if deleted {
+			freed++
+		}
+	}
+}
@@ -0,0 +1,66 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package blobstore_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"lang.yottadb.com/go/yottadb/blobstore"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestPutGetDelete(t *testing.T) {
+	store := blobstore.New()
+	store.ChunkSize = 100
+	content := bytes.Repeat([]byte{0, 1, 'a', 255}, 1000) // Spans 40 chunks
+	hash, err := store.Put(yottadb.NOTTP, nil, content)
+	Assertnoerr(err, t)
+	assert.Equal(t, blobstore.Hash(content), hash)
+	got, err := store.Get(yottadb.NOTTP, nil, hash)
+	Assertnoerr(err, t)
+	assert.Equal(t, content, got)
+	// Storing the same content again counts another reference
+	_, err = store.Put(yottadb.NOTTP, nil, content)
+	Assertnoerr(err, t)
+	refs, err := store.Refs(yottadb.NOTTP, nil, hash)
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, refs)
+	empty, err := store.Put(yottadb.NOTTP, nil, []byte{})
+	Assertnoerr(err, t)
+	got, err = store.Get(yottadb.NOTTP, nil, empty)
+	Assertnoerr(err, t)
+	assert.Equal(t, []byte{}, got)
+	// The blob is only freed once all references are deleted
+	Assertnoerr(store.Delete(yottadb.NOTTP, nil, hash), t)
+	freed, err := store.Collect(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, freed)
+	Assertnoerr(store.Delete(yottadb.NOTTP, nil, hash), t)
+	Assertnoerr(store.Delete(yottadb.NOTTP, nil, empty), t)
+	_, err = store.Get(yottadb.NOTTP, nil, hash)
+	assert.Equal(t, blobstore.ErrNotFound, err)
+	assert.Equal(t, blobstore.ErrNotFound, store.Delete(yottadb.NOTTP, nil, hash))
+	freed, err = store.Collect(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, freed)
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, store.Global, []string{hash})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
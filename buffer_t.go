@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -76,14 +76,14 @@ func (buft *BufferT) Alloc(nBytes uint32) {
 	}
 
 	// Allocate a C flavor ydb_buffer_t struct to pass to simpleAPI
-	buft.cbuft = &internalBufferT{(*C.ydb_buffer_t)(allocMem(C.size_t(C.sizeof_ydb_buffer_t)))}
+	buft.cbuft = &internalBufferT{(*C.ydb_buffer_t)(allocMem(C.size_t(C.sizeof_ydb_buffer_t), memCatBufferT))}
 	cbuftptr = buft.getCPtr()
 	cbuftptr.len_used = 0
 	cbuftptr.len_alloc = C.uint(nBytes)
 	cbuftptr.buf_addr = nil
 	// Allocate a new buffer of the given size; if size is 0, we just leave it as nil
 	if 0 < nBytes {
-		cbuftptr.buf_addr = (*C.char)(allocMem(C.size_t(nBytes)))
+		cbuftptr.buf_addr = (*C.char)(allocMem(C.size_t(nBytes), memCatBufferT))
 	}
 	buft.ownsBuff = true
 	// Set a finalizer
@@ -127,7 +127,7 @@ func (buft *BufferT) DumpToWriter(writer io.Writer) {
 		}
 		if 0 < min {
 			strval := C.GoStringN(cbuftptr.buf_addr, C.int(min))
-			fmt.Fprintf(writer, ", value: %s", strval)
+			fmt.Fprintf(writer, ", value: %s", redactValue("", strval))
 		}
 	}
 	fmt.Fprintf(writer, "\n")
@@ -158,9 +158,9 @@ func (ibuft *internalBufferT) Free() {
 	if nil != cbuftptr {
 		// ydb_buffer_t block exists - free its buffer first if it exists
 		if nil != cbuftptr.buf_addr {
-			freeMem(unsafe.Pointer(cbuftptr.buf_addr), C.size_t(cbuftptr.len_alloc))
+			freeMem(unsafe.Pointer(cbuftptr.buf_addr), C.size_t(cbuftptr.len_alloc), memCatBufferT)
 		}
-		freeMem(unsafe.Pointer(cbuftptr), C.sizeof_ydb_buffer_t)
+		freeMem(unsafe.Pointer(cbuftptr), C.sizeof_ydb_buffer_t, memCatBufferT)
 		// The below keeps ibuft around long enough to get rid of this block's C memory. No KeepAlive() necessary.
 		ibuft.cbuft = nil
 	}
@@ -191,7 +191,7 @@ func (buft *BufferT) LenAlloc(tptoken uint64, errstr *BufferT) (uint32, error) {
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return 0, &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return 0, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	retval := uint32(cbuftptr.len_alloc)
 	runtime.KeepAlive(buft)
@@ -215,7 +215,7 @@ func (buft *BufferT) LenUsed(tptoken uint64, errstr *BufferT) (uint32, error) {
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return 0, &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return 0, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	lenused := cbuftptr.len_used
 	runtime.KeepAlive(buft)
@@ -241,7 +241,7 @@ func (buft *BufferT) ValBAry(tptoken uint64, errstr *BufferT) ([]byte, error) {
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return nil, &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return nil, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	lenalloc := cbuftptr.len_alloc
 	lenused := cbuftptr.len_used
@@ -249,7 +249,7 @@ func (buft *BufferT) ValBAry(tptoken uint64, errstr *BufferT) ([]byte, error) {
 	if lenused > lenalloc { // INVSTRLEN from last operation - return what we can and give error
 		bary = C.GoBytes(unsafe.Pointer(cbufptr), C.int(lenalloc)) // Return what we can (alloc size)
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, lenused)
-		return bary, &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return bary, &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// The entire buffer is there so return that.
 	bary = C.GoBytes(unsafe.Pointer(cbufptr), C.int(lenused))
@@ -257,6 +257,36 @@ func (buft *BufferT) ValBAry(tptoken uint64, errstr *BufferT) ([]byte, error) {
 	return bary, nil
 }
 
+// ValAppend is a method to append the buffer contents to buf and return the extended slice, as append() does. The contents
+// are copied straight from the C buffer into buf, so no memory is allocated when buf has room for them - for example when
+// buf is reused between calls (as buf[:0]) in a server handling many requests.
+//
+// If the C.ydb_buffer_t structure referenced by cbuft has not yet been allocated, return the STRUCTUNALLOCD error.
+// If the len_used field of the C.ydb_buffer_t structure is greater than its len_alloc field (owing to a prior
+// INVSTRLEN error), append len_alloc bytes and return an INVSTRLEN error.
+func (buft *BufferT) ValAppend(tptoken uint64, errstr *BufferT, buf []byte) ([]byte, error) {
+	printEntry("BufferT.ValAppend()")
+	if nil == buft {
+		panic("YDB: *BufferT receiver of ValAppend() cannot be nil")
+	}
+	cbuftptr := buft.getCPtr()
+	if nil == cbuftptr {
+		// Create an error to return
+		errmsg, err := MessageT(tptoken, errstr, (int)(YDB_ERR_STRUCTUNALLOCD))
+		if nil != err {
+			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
+		}
+		return buf, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
+	}
+	buf = append(buf, bufferBytes(cbuftptr)...)
+	if cbuftptr.len_used > cbuftptr.len_alloc { // INVSTRLEN from last operation - bufferBytes() returned what it could
+		errmsg := formatINVSTRLEN(tptoken, errstr, cbuftptr.len_alloc, cbuftptr.len_used)
+		return buf, &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
+	}
+	runtime.KeepAlive(buft) // Make sure buft hangs around
+	return buf, nil
+}
+
 // ValStr is a method to fetch the buffer contents as a string.
 //
 // If the C.ydb_buffer_t structure referenced by cbuft has not yet been allocated, return the STRUCTUNALLOCD error.
@@ -276,7 +306,7 @@ func (buft *BufferT) ValStr(tptoken uint64, errstr *BufferT) (string, error) {
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return "", &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return "", &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	lenalloc := cbuftptr.len_alloc
 	lenused := cbuftptr.len_used
@@ -284,7 +314,7 @@ func (buft *BufferT) ValStr(tptoken uint64, errstr *BufferT) (string, error) {
 	if lenused > lenalloc { // INVSTRLEN from last operation - return what we can and give error
 		str = C.GoStringN(cbufptr, C.int(lenalloc)) // Return what we can (alloc size)
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, lenused)
-		return str, &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return str, &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// The entire buffer is there so return that
 	str = C.GoStringN(cbufptr, C.int(lenused))
@@ -315,12 +345,12 @@ func (buft *BufferT) SetLenUsed(tptoken uint64, errstr *BufferT, newLen uint32)
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	lenalloc := cbuftptr.len_alloc
 	if newLen > uint32(lenalloc) {
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, C.uint(newLen))
-		return &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	cbuftptr.len_used = C.uint(newLen)
 	runtime.KeepAlive(buft) // Make sure buft hangs around
@@ -345,13 +375,13 @@ func (buft *BufferT) SetValBAry(tptoken uint64, errstr *BufferT, value []byte) e
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	vallen := C.uint(len(value))
 	lenalloc := cbuftptr.len_alloc
 	if vallen > lenalloc {
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, vallen)
-		return &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// Copy the Go buffer to the C buffer
 	if 0 < vallen {
@@ -402,20 +432,23 @@ func (buft *BufferT) Str2ZwrST(tptoken uint64, errstr *BufferT, zwr *BufferT) er
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil == buft.getCPtr() || nil == zwr.getCPtr() {
 		// Create an error to return
 		errmsg, err := MessageT(tptoken, errstr, (int)(YDB_ERR_STRUCTUNALLOCD))
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
 	rc := C.ydb_str2zwr_st(C.uint64_t(tptoken), cbuft, buft.getCPtr(), zwr.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "BufferT.Str2ZwrST")
 		return err
 	}
 	runtime.KeepAlive(buft)
@@ -446,20 +479,23 @@ func (buft *BufferT) Zwr2StrST(tptoken uint64, errstr *BufferT, str *BufferT) er
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil == buft.getCPtr() || nil == str.getCPtr() {
 		// Create an error to return
 		errmsg, err := MessageT(tptoken, errstr, (int)(YDB_ERR_STRUCTUNALLOCD))
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
 	rc := C.ydb_zwr2str_st(C.uint64_t(tptoken), cbuft, buft.getCPtr(), str.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "BufferT.Zwr2StrST")
 		return err
 	}
 	runtime.KeepAlive(buft)
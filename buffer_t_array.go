@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -19,6 +19,8 @@ import (
 	"runtime"
 	"sync"
 	"sync/atomic"
+	"syscall"
+	"time"
 	"unsafe"
 )
 
@@ -31,6 +33,21 @@ import "C"
 // Variables used by TpST to wrap passing in func so the callback can retrieve it without passing pointers to C.
 var tpIndex uint64
 var tpMap sync.Map
+var tpCauseMap sync.Map // Causes passed to TpRollback(), indexed the same as tpMap, for TpST to wrap in the ROLLBACK error
+
+// Variables used to detect calls that pass NOTTP from within a transaction closure, which would otherwise deadlock
+var tpThreads sync.Map     // Stack of tptokens of transaction closures running on each OS thread, indexed by thread id
+var tpClosureCount int32   // Count of transaction closures running in the process - checks are only needed when non-zero
+var tpImplicitToken uint32 // Set to 1 by SetImplicitTPToken(true) to substitute the closure's tptoken for NOTTP
+var tpForcedRestarts int64 // Atomic: Number of commits still to be turned into restarts (see ForceTpRestarts())
+
+// tpRestartSignal is the value TpRestart() panics with for ydbTpStWrapper to recover
+type tpRestartSignal struct{}
+
+// tpRollbackSignal is the value TpRollback() panics with for ydbTpStWrapper to recover
+type tpRollbackSignal struct {
+	cause error // The reason for the rollback, if any
+}
 
 // BufferTArray is an array of ydb_buffer_t structures. The reason this is not an array of BufferT structures is because
 // we can't pass a pointer to those Go structures to a C routine (cgo restriction) so we have to have this separate
@@ -67,13 +84,16 @@ func (buftary *BufferTArray) Alloc(numBufs, nBytes uint32) {
 	if 0 != numBufs {
 		// Allocate new ydb_buffer_t array and initialize
 		len := C.size_t(uint32(C.sizeof_ydb_buffer_t) * numBufs)
-		cbuftary := (*[]C.ydb_buffer_t)(allocMem(len))
+		cbuftary := (*[]C.ydb_buffer_t)(allocMem(len, memCatBufferTArray))
 		buftary.cbuftary = &internalBufferTArray{0, numBufs, cbuftary}
-		// Allocate a buffer for each ydb_buffer_t structure of nBytes bytes
+		// Allocate a buffer for each ydb_buffer_t structure of nBytes bytes; if size is 0, we just leave it as nil
 		for i = 0; numBufs > i; i++ {
 			elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) +
 				uintptr(C.sizeof_ydb_buffer_t*i)))
-			elemptr.buf_addr = (*C.char)(allocMem(C.size_t(nBytes)))
+			elemptr.buf_addr = nil
+			if 0 < nBytes {
+				elemptr.buf_addr = (*C.char)(allocMem(C.size_t(nBytes), memCatBufferTArray))
+			}
 			elemptr.len_alloc = C.uint(nBytes)
 			elemptr.len_used = 0
 		}
@@ -114,7 +134,7 @@ func (buftary *BufferTArray) DumpToWriter(writer io.Writer) {
 				min = elemptr.len_alloc
 			}
 			valstr := C.GoStringN(elemptr.buf_addr, C.int(min))
-			fmt.Fprintf(writer, "  %d: %s\n", i, valstr)
+			fmt.Fprintf(writer, "  %d: %s\n", i, redactValue("", valstr))
 		}
 	}
 	runtime.KeepAlive(buftary)
@@ -145,12 +165,12 @@ func (ibuftary *internalBufferTArray) Free() {
 	for i := 0; int(ibuftary.elemAlloc) > i; i++ {
 		elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) +
 			uintptr(C.sizeof_ydb_buffer_t*i)))
-		if 0 != elemptr.len_alloc {
-			freeMem(unsafe.Pointer(elemptr.buf_addr), C.size_t(elemptr.len_alloc))
+		if nil != elemptr.buf_addr {
+			freeMem(unsafe.Pointer(elemptr.buf_addr), C.size_t(elemptr.len_alloc), memCatBufferTArray)
 		}
 	}
 	// Array buffers are freed, now free the array of ydb_buffer_t structs if it exists
-	freeMem(unsafe.Pointer(cbuftary), C.size_t(C.sizeof_ydb_buffer_t*ibuftary.elemAlloc))
+	freeMem(unsafe.Pointer(cbuftary), C.size_t(C.sizeof_ydb_buffer_t*ibuftary.elemAlloc), memCatBufferTArray)
 	// The below keeps ibuftary around long enough to get rid of this block's C memory. No KeepAlive() necessary.
 	ibuftary.cbuftary = nil
 }
@@ -210,7 +230,7 @@ func (buftary *BufferTArray) ElemLenUsed(tptoken uint64, errstr *BufferT, idx ui
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return 0, &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return 0, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	elemcnt := buftary.ElemAlloc()
 	if idx >= elemcnt { // Request for non-existant element
@@ -219,7 +239,7 @@ func (buftary *BufferTArray) ElemLenUsed(tptoken uint64, errstr *BufferT, idx ui
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return 0, &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return 0, &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx)))
 	retlen := uint32(elemptr.len_used)
@@ -254,7 +274,7 @@ func (buftary *BufferTArray) ValBAry(tptoken uint64, errstr *BufferT, idx uint32
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return nil, &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return nil, &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	cbuftary := buftary.getCPtr()
 	if nil == cbuftary {
@@ -263,7 +283,7 @@ func (buftary *BufferTArray) ValBAry(tptoken uint64, errstr *BufferT, idx uint32
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return nil, &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return nil, &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx)))
 	lenalloc := elemptr.len_alloc
@@ -272,7 +292,7 @@ func (buftary *BufferTArray) ValBAry(tptoken uint64, errstr *BufferT, idx uint32
 	if lenused > lenalloc { // INVSTRLEN from last operation return what we can and give error
 		bary = C.GoBytes(unsafe.Pointer(cbufptr), C.int(lenalloc)) // Return what we can (alloc size)
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, lenused)
-		return bary, &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return bary, &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// The entire buffer is there so return that
 	bary = C.GoBytes(unsafe.Pointer(cbufptr), C.int(lenused))
@@ -295,7 +315,7 @@ func (buftary *BufferTArray) ValStr(tptoken uint64, errstr *BufferT, idx uint32)
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return "", &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return "", &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	cbuftary := buftary.getCPtr()
 	if nil == cbuftary {
@@ -304,7 +324,7 @@ func (buftary *BufferTArray) ValStr(tptoken uint64, errstr *BufferT, idx uint32)
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return "", &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return "", &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx)))
 	lenalloc := elemptr.len_alloc
@@ -313,7 +333,7 @@ func (buftary *BufferTArray) ValStr(tptoken uint64, errstr *BufferT, idx uint32)
 	if lenused > lenalloc { // INVSTRLEN from last operation return what we can and give error
 		str = C.GoStringN(cbufptr, C.int(lenalloc)) // Return what we can (alloc size)
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, lenused)
-		return str, &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return str, &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// The entire buffer is there so return that
 	str = C.GoStringN(cbufptr, C.int(lenused))
@@ -334,7 +354,7 @@ func (buftary *BufferTArray) SetElemLenUsed(tptoken uint64, errstr *BufferT, idx
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	cbuftary := buftary.getCPtr()
 	if nil == cbuftary {
@@ -343,13 +363,13 @@ func (buftary *BufferTArray) SetElemLenUsed(tptoken uint64, errstr *BufferT, idx
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx)))
 	lenalloc := elemptr.len_alloc
 	if newLen > uint32(lenalloc) { // INVSTRLEN from last operation - return what we can and give error
 		errmsg := formatINVSTRLEN(tptoken, errstr, lenalloc, C.uint(newLen))
-		return &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// Set the new used length
 	elemptr.len_used = C.uint(newLen)
@@ -370,7 +390,7 @@ func (buftary *BufferTArray) SetElemUsed(tptoken uint64, errstr *BufferT, newUse
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	// Set the number of used buffers
 	if nil != buftary.cbuftary {
@@ -392,7 +412,7 @@ func (buftary *BufferTArray) SetValBAry(tptoken uint64, errstr *BufferT, idx uin
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INSUFFSUBS: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_INSUFFSUBS), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INSUFFSUBS), errmsg: errmsg}
 	}
 	cbuftary := buftary.getCPtr()
 	if nil == cbuftary {
@@ -401,14 +421,14 @@ func (buftary *BufferTArray) SetValBAry(tptoken uint64, errstr *BufferT, idx uin
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx)))
 	lenalloc := uint32(elemptr.len_alloc)
 	vallen := uint32(len(value))
 	if vallen > lenalloc { // INVSTRLEN from last operation - return what we can and give error
 		errmsg := formatINVSTRLEN(tptoken, errstr, C.uint(lenalloc), C.uint(vallen))
-		return &YDBError{(int)(YDB_ERR_INVSTRLEN), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: errmsg}
 	}
 	// Copy the Go buffer to the C buffer
 	if 0 < vallen {
@@ -447,18 +467,24 @@ func (buftary *BufferTArray) DeleteExclST(tptoken uint64, errstr *BufferT) error
 	var cbuft *C.ydb_buffer_t
 
 	printEntry("BufferTArray.DeleteExclST()")
+	if hookEnabled(hookStats) {
+		defer recordStat(statKill, time.Now())
+	}
 	if nil == buftary {
 		panic("YDB: *BufferTArray receiver of DeleteExclST() cannot be nil")
 	}
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
 	rc := C.ydb_delete_excl_st(C.uint64_t(tptoken), cbuft, C.int(buftary.ElemUsed()), buftary.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "BufferTArray.DeleteExclST")
 		return err
 	}
 	runtime.KeepAlive(buftary)
@@ -498,14 +524,20 @@ func (buftary *BufferTArray) TpST(tptoken uint64, errstr *BufferT, tpfn func(uin
 	var cbuft *C.ydb_buffer_t
 
 	printEntry("TpST()")
+	if hookEnabled(hookStats) {
+		defer recordStat(statTxn, time.Now())
+	}
 	if nil == buftary {
 		panic("YDB: *BufferTArray receiver of TpST() cannot be nil")
 	}
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
-	tid := C.CString(transid)
-	defer freeMem(unsafe.Pointer(tid), C.size_t(len(transid)))
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
+	tid := allocCString(transid, memCatParms)
+	defer freeMem(unsafe.Pointer(tid), C.size_t(len(transid)+1), memCatParms)
 	tpfnparm := atomic.AddUint64(&tpIndex, 1)
 	tpMap.Store(tpfnparm, tpfn)
 	if nil != errstr {
@@ -515,8 +547,13 @@ func (buftary *BufferTArray) TpST(tptoken uint64, errstr *BufferT, tpfn func(uin
 	rc := C.ydb_tp_st(C.uint64_t(tptoken), cbuft, (C.ydb_tpfnptr_t)(C.ydb_tp_st_wrapper_cgo),
 		unsafe.Pointer(&tpfnparm), tid, C.int(buftary.ElemUsed()), cbuftary)
 	tpMap.Delete(tpfnparm)
+	cause, _ := tpCauseMap.LoadAndDelete(tpfnparm)
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "BufferTArray.TpST")
+		if nil != cause && YDB_TP_ROLLBACK == rc {
+			// Return a distinct error (not the shared shortcut ROLLBACK error) wrapping the cause of the rollback
+			err = &YDBError{errcode: int(rc), errmsg: "ROLLBACK: " + cause.(error).Error(), cause: cause.(error)}
+		}
 		return err
 	}
 	runtime.KeepAlive(buftary)
@@ -526,7 +563,7 @@ func (buftary *BufferTArray) TpST(tptoken uint64, errstr *BufferT, tpfn func(uin
 
 // YdbTpStWrapper is a private callback to wrap calls to the Go closure required for TpST.
 //export ydbTpStWrapper
-func ydbTpStWrapper(tptoken uint64, errstr *C.ydb_buffer_t, tpfnparm unsafe.Pointer) int32 {
+func ydbTpStWrapper(tptoken uint64, errstr *C.ydb_buffer_t, tpfnparm unsafe.Pointer) (retval int32) {
 	var errbuff BufferT
 
 	index := *((*uint64)(tpfnparm))
@@ -534,8 +571,144 @@ func ydbTpStWrapper(tptoken uint64, errstr *C.ydb_buffer_t, tpfnparm unsafe.Poin
 	if !ok {
 		panic("YDB: Could not find callback routine")
 	}
+	// The closure runs on this thread so note that, for checkNestedTPToken() to detect calls from it that use NOTTP
+	tid := enterTPClosure(tptoken)
+	defer exitTPClosure(tid)
+	// Turn the panics of TpRestart() and TpRollback() into the return codes that drive the engine. Other panics continue.
+	defer func() {
+		if r := recover(); nil != r {
+			switch signal := r.(type) {
+			case *tpRestartSignal:
+				retval = YDB_TP_RESTART
+			case *tpRollbackSignal:
+				if nil != signal.cause {
+					tpCauseMap.Store(index, signal.cause)
+				}
+				retval = YDB_TP_ROLLBACK
+			default:
+				panic(r)
+			}
+		}
+	}()
 	errbuff.bufferTFromPtr((unsafe.Pointer)(errstr))
-	retval := (v.(func(uint64, *BufferT) int32))(tptoken, &errbuff)
+	retval = (v.(func(uint64, *BufferT) int32))(tptoken, &errbuff)
 	runtime.KeepAlive(errstr)
+	if YDB_OK == retval && 0 < atomic.LoadInt64(&tpForcedRestarts) && 0 <= atomic.AddInt64(&tpForcedRestarts, -1) {
+		retval = YDB_TP_RESTART
+	}
 	return retval
 }
+
+// ForceTpRestarts is a function for tests to turn the next n commits of transaction closures in the process into
+// restarts, as if each closure returned YDB_TP_RESTART instead of YDB_OK, so tests can check that closures are safe to
+// invoke again without racing concurrent updates to cause the restarts. With n restarts forced, the closure of the next
+// transaction to commit is invoked n+1 times, and sees $TRESTART counting the forced restarts. Closures that return an
+// error or roll back do not use up forced restarts. Passing 0 cancels the restarts not yet forced.
+//
+// ForceTpRestarts() panics if n is negative.
+func ForceTpRestarts(n int) {
+	printEntry("ForceTpRestarts()")
+	if 0 > n {
+		panic("YDB: ForceTpRestarts() called with a negative count")
+	}
+	atomic.StoreInt64(&tpForcedRestarts, int64(n))
+}
+
+// TpRestart is a function to restart the transaction whose closure (as passed to TpE(), TpST() or TpValE()) is running,
+// as if the closure returned YDB_TP_RESTART. It does not return - it panics with a value the transaction wrapper recovers
+// from, so it may be called from functions the closure calls, at any depth. The closure is invoked again once the engine
+// has restarted the transaction.
+//
+// TpRestart() must only be called from the goroutine running the closure, and deferred functions that call recover() must
+// re-panic values they do not recognize. Called outside a transaction closure, it panics.
+func TpRestart() {
+	printEntry("TpRestart()")
+	panic(&tpRestartSignal{})
+}
+
+// TpRollback is a function to roll back the transaction whose closure (as passed to TpE(), TpST() or TpValE()) is running,
+// as if the closure returned YDB_TP_ROLLBACK. Like TpRestart(), it does not return and may be called at any depth. The
+// transaction function returns a ROLLBACK error which, if cause is not nil, includes the text of cause and wraps it, so
+// errors.Is() and errors.As() find it.
+//
+// TpRollback() must only be called from the goroutine running the closure. Called outside a transaction closure, it panics.
+func TpRollback(cause error) {
+	printEntry("TpRollback()")
+	panic(&tpRollbackSignal{cause: cause})
+}
+
+// Error is a method to describe the panic of a TpRestart() that is not recovered (i.e. called outside a transaction).
+func (signal *tpRestartSignal) Error() string {
+	return "YDB: TpRestart() called outside of a transaction closure"
+}
+
+// Error is a method to describe the panic of a TpRollback() that is not recovered (i.e. called outside a transaction).
+func (signal *tpRollbackSignal) Error() string {
+	return "YDB: TpRollback() called outside of a transaction closure"
+}
+
+// enterTPClosure is a function to record that a transaction closure with the given tptoken is starting to run on the current
+// OS thread, and return the thread id to pass to exitTPClosure(). As the closure is called back from C, its goroutine is
+// locked to the thread until it returns, so no other goroutine runs on the thread meanwhile: the thread identifies the
+// goroutine, without the cost of parsing a stack trace for its id.
+func enterTPClosure(tptoken uint64) int {
+	var tptokens []uint64
+
+	tid := syscall.Gettid()
+	if prev, ok := tpThreads.Load(tid); ok {
+		tptokens = prev.([]uint64)
+	}
+	tpThreads.Store(tid, append(tptokens, tptoken))
+	atomic.AddInt32(&tpClosureCount, 1)
+	return tid
+}
+
+// exitTPClosure is a function to record that the innermost transaction closure running on the given thread has finished.
+func exitTPClosure(tid int) {
+	atomic.AddInt32(&tpClosureCount, -1)
+	prev, _ := tpThreads.Load(tid)
+	tptokens := prev.([]uint64)
+	if 1 == len(tptokens) {
+		tpThreads.Delete(tid)
+	} else {
+		tpThreads.Store(tid, tptokens[:len(tptokens)-1])
+	}
+}
+
+// checkNestedTPToken is a function to detect a call that passes NOTTP as its tptoken from within a transaction closure
+// (or code it calls on the same goroutine). Such a call waits for the transaction to complete, which it never does as the
+// transaction is waiting for the closure, so the process would hang. If SetImplicitTPToken(true) is in effect, *tptoken is
+// replaced by the tptoken of the innermost closure running on the goroutine. Otherwise the NESTEDTPTOKEN error is returned.
+func checkNestedTPToken(tptoken *uint64) error {
+	if NOTTP != *tptoken || 0 == atomic.LoadInt32(&tpClosureCount) {
+		return nil // Fast path - only a NOTTP call while a transaction closure is running anywhere needs checking
+	}
+	prev, ok := tpThreads.Load(syscall.Gettid())
+	if !ok {
+		return nil // A different goroutine - it will wait for the transaction to complete but that does not deadlock
+	}
+	if 1 == atomic.LoadUint32(&tpImplicitToken) {
+		tptokens := prev.([]uint64)
+		*tptoken = tptokens[len(tptokens)-1]
+		return nil
+	}
+	return &YDBError{errcode: (int)(YDB_ERR_NESTEDTPTOKEN), errmsg: getWrapperErrorMsg(YDB_ERR_NESTEDTPTOKEN)}
+}
+
+// SetImplicitTPToken is a function to enable or disable implicit tptokens. By default, a call that passes NOTTP from
+// within a transaction closure (as passed to TpE(), TpST() or TpValE()), or from code the closure calls on the same
+// goroutine, returns the NESTEDTPTOKEN error because the call would otherwise wait forever for the transaction to complete.
+// With implicit tptokens enabled, such a call instead uses the tptoken of the transaction, so helper functions written
+// to use NOTTP work unchanged inside transactions.
+//
+// Calls from other goroutines, including goroutines the closure starts, are not affected and must still be passed the
+// tptoken explicitly to take part in the transaction. Determining the goroutine of a NOTTP call costs a system call, which
+// is only made while a transaction closure is running.
+func SetImplicitTPToken(enable bool) {
+	printEntry("SetImplicitTPToken()")
+	if enable {
+		atomic.StoreUint32(&tpImplicitToken, 1)
+	} else {
+		atomic.StoreUint32(&tpImplicitToken, 0)
+	}
+}
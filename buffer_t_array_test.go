@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -14,6 +14,7 @@ package yottadb_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"lang.yottadb.com/go/yottadb"
@@ -575,3 +576,119 @@ func TestBufferTArrayFinalizerCleansCAlloc(t *testing.T) {
 		assert.InEpsilon(t, mem_before, mem_after, .2)
 	}()
 }
+
+// TestTpRestartAndRollback tests that TpRestart() and TpRollback() restart and roll back transactions from any depth.
+func TestTpRestartAndRollback(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// A restart re-runs the closure, with the updates of the restarted attempt undone
+	tries := 0
+	err := yottadb.TpE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		tries++
+		_, err := yottadb.IncrE(tptoken, errstr, "", "^tprestart", []string{})
+		Assertnoerr(err, t)
+		if 3 > tries {
+			func() { yottadb.TpRestart() }() // From a nested call
+		}
+		return yottadb.YDB_OK
+	}, "", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, 3, tries)
+	val, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^tprestart", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, "1", val)
+	// A rollback undoes the updates and returns a ROLLBACK error wrapping its cause
+	errCause := errors.New("insufficient funds")
+	err = yottadb.TpE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		_, err := yottadb.IncrE(tptoken, errstr, "", "^tprestart", []string{})
+		Assertnoerr(err, t)
+		yottadb.TpRollback(errCause)
+		return yottadb.YDB_OK
+	}, "", []string{})
+	assert.Equal(t, yottadb.YDB_TP_ROLLBACK, yottadb.ErrorCode(err))
+	assert.True(t, errors.Is(err, errCause))
+	assert.Contains(t, err.Error(), "insufficient funds")
+	val, err = yottadb.ValE(yottadb.NOTTP, &errstr, "^tprestart", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, "1", val)
+	// A rollback without a cause returns the plain ROLLBACK error
+	_, err = yottadb.TpValE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) (int, error) {
+		yottadb.TpRollback(nil)
+		return 0, nil
+	}, "", []string{})
+	assert.Equal(t, yottadb.YDB_TP_ROLLBACK, yottadb.ErrorCode(err))
+	assert.Nil(t, errors.Unwrap(err))
+	// Outside a transaction they are ordinary panics
+	assert.Panics(t, func() { yottadb.TpRestart() })
+	assert.Panics(t, func() { yottadb.TpRollback(errCause) })
+}
+
+// TestForceTpRestarts tests that forced restarts re-run transaction closures without concurrent updates.
+func TestForceTpRestarts(t *testing.T) {
+	var errstr yottadb.BufferT
+	var trestarts []string
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^forcedrestart", []string{})
+	yottadb.ForceTpRestarts(2)
+	defer yottadb.ForceTpRestarts(0)
+	val, err := yottadb.TpValE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) (string, error) {
+		trestart, err := yottadb.ValE(tptoken, errstr, "$TRESTART", []string{})
+		Assertnoerr(err, t)
+		trestarts = append(trestarts, trestart)
+		return yottadb.IncrE(tptoken, errstr, "1", "^forcedrestart", []string{})
+	}, "", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"0", "1", "2"}, trestarts)
+	assert.Equal(t, "1", val)
+	// Once used up, transactions commit the first time
+	trestarts = nil
+	err = yottadb.TpE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		trestarts = append(trestarts, "")
+		return yottadb.YDB_OK
+	}, "", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, 1, len(trestarts))
+	assert.Panics(t, func() { yottadb.ForceTpRestarts(-1) })
+}
+
+func TestSetImplicitTPToken(t *testing.T) {
+	var helperErr error
+
+	// A helper written without a tptoken, as is common in code that predates transactions
+	helper := func() error {
+		_, err := yottadb.IncrE(yottadb.NOTTP, nil, "", "^implicittp", []string{})
+		return err
+	}
+	tpfn := func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		helperErr = helper()
+		if nil != helperErr {
+			return yottadb.YDB_TP_ROLLBACK
+		}
+		return yottadb.YDB_OK
+	}
+	err := yottadb.TpE(yottadb.NOTTP, nil, tpfn, "", []string{})
+	assert.Equal(t, yottadb.YDB_TP_ROLLBACK, yottadb.ErrorCode(err))
+	assert.Equal(t, yottadb.YDB_ERR_NESTEDTPTOKEN, yottadb.ErrorCode(helperErr))
+	yottadb.SetImplicitTPToken(true)
+	defer yottadb.SetImplicitTPToken(false)
+	err = yottadb.TpE(yottadb.NOTTP, nil, tpfn, "", []string{})
+	assert.Nil(t, err)
+	assert.Nil(t, helperErr)
+	// The helper also works in a nested transaction and outside transactions
+	err = yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		err := yottadb.TpE(tptoken, errstr, tpfn, "", []string{})
+		if nil != err {
+			return int32(yottadb.ErrorCode(err))
+		}
+		return yottadb.YDB_OK
+	}, "", []string{})
+	assert.Nil(t, err)
+	assert.Nil(t, helper())
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^implicittp", []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "3", val)
+}
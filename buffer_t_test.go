@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -488,3 +488,17 @@ func TestBufferTInStruct(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, val, "")
 }
+
+func TestValAppend(t *testing.T) {
+	var value yottadb.BufferT
+
+	buf, err := value.ValAppend(yottadb.NOTTP, nil, []byte("x"))
+	assert.Equal(t, yottadb.YDB_ERR_STRUCTUNALLOCD, yottadb.ErrorCode(err))
+	assert.Equal(t, []byte("x"), buf)
+	defer value.Free()
+	value.Alloc(16)
+	Assertnoerr(value.SetValStr(yottadb.NOTTP, nil, "hello"), t)
+	buf, err = value.ValAppend(yottadb.NOTTP, nil, buf)
+	Assertnoerr(err, t)
+	assert.Equal(t, []byte("xhello"), buf)
+}
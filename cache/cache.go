@@ -0,0 +1,190 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package cache provides a key-value cache with expiry stored in YottaDB, with the Get, Set, Delete and TTL operations of
+in-memory caches such as go-cache, so code written against a cache can keep its entries durably and share them among
+processes.
+
+An entry is stored in the ^cache(key,"value") node, and its expiry time, in nanoseconds since the Unix epoch, in the
+^cache(key,"expiry") node, which is absent for an entry that does not expire. For example:
+
+	c := cache.New(10 * time.Minute)
+	err := c.Set("user:42", []byte(profile), cache.DefaultExpiration)
+	...
+	profile, found, err := c.Get("user:42")
+
+Expired entries are not returned but stay in the database until DeleteExpired() deletes them, so a long running
+application should call it periodically. A Cache accesses the database with NOTTP, so it cannot be used within a
+transaction. A value must fit in the value of a node (1MiB).
+*/
+package cache
+
+import (
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() stores entries in
+const DefaultGlobal string = "^cache"
+
+// Special values for the ttl parameter of Set()
+const (
+	NoExpiration      time.Duration = -1 // The entry does not expire
+	DefaultExpiration time.Duration = 0  // The entry expires after the DefaultTTL of the cache
+)
+
+// Cache is a cache keeping entries in Global(key,...) nodes
+type Cache struct {
+	Global     string        // Global variable name holding entries, e.g. "^cache"
+	DefaultTTL time.Duration // Time to live of entries set with DefaultExpiration, or NoExpiration
+}
+
+// entry is a structure holding an entry as stored in the database
+type entry struct {
+	value  string    // Value of the entry
+	expiry time.Time // Time the entry expires, or the zero time if it does not
+	found  bool      // Whether the entry exists
+}
+
+// New is a function to return the cache stored in DefaultGlobal, with the given default time to live for entries. A
+// defaultTTL of zero or NoExpiration means entries set with DefaultExpiration do not expire.
+func New(defaultTTL time.Duration) *Cache {
+	if 0 == defaultTTL {
+		defaultTTL = NoExpiration
+	}
+	return &Cache{Global: DefaultGlobal, DefaultTTL: defaultTTL}
+}
+
+// Get is a method to return the value of the entry with the given key, and whether the entry was found and has not expired
+func (cache *Cache) Get(key string) ([]byte, bool, error) {
+	ent, err := cache.read(key)
+	if nil != err || !ent.found || expired(ent, time.Now()) {
+		return nil, false, err
+	}
+	return []byte(ent.value), true, nil
+}
+
+// TTL is a method to return the time left before the entry with the given key expires, or NoExpiration if it does not
+// expire, and whether the entry was found and has not expired
+func (cache *Cache) TTL(key string) (time.Duration, bool, error) {
+	now := time.Now()
+	ent, err := cache.read(key)
+	if nil != err || !ent.found || expired(ent, now) {
+		return 0, false, err
+	}
+	if ent.expiry.IsZero() {
+		return NoExpiration, true, nil
+	}
+	return ent.expiry.Sub(now), true, nil
+}
+
+// Set is a method to add or replace the entry with the given key, expiring after ttl. A ttl of DefaultExpiration uses the
+// DefaultTTL of the cache and a ttl of NoExpiration means the entry does not expire.
+func (cache *Cache) Set(key string, value []byte, ttl time.Duration) error {
+	if DefaultExpiration == ttl {
+		ttl = cache.DefaultTTL
+	}
+	_, err := yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		if err := yottadb.SetValE(tptoken, errstr, string(value), cache.Global, []string{key, "value"}); nil != err {
+			return struct{}{}, err
+		}
+		if 0 > ttl {
+			return struct{}{}, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_NODE, cache.Global, []string{key, "expiry"})
+		}
+		expiry := strconv.FormatInt(time.Now().Add(ttl).UnixNano(), 10)
+		return struct{}{}, yottadb.SetValE(tptoken, errstr, expiry, cache.Global, []string{key, "expiry"})
+	}, "", []string{})
+	return err
+}
+
+// Delete is a method to delete the entry with the given key. Deleting an entry that does not exist is not an error.
+func (cache *Cache) Delete(key string) error {
+	return yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, cache.Global, []string{key})
+}
+
+// Flush is a method to delete every entry of the cache
+func (cache *Cache) Flush() error {
+	return yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, cache.Global, []string{})
+}
+
+// DeleteExpired is a method to delete every expired entry, and return the number of entries deleted
+func (cache *Cache) DeleteExpired() (int, error) {
+	var deleted int
+	var delerr error
+
+	err := yottadb.SubRangeE(yottadb.NOTTP, nil, cache.Global, []string{}, "", "", func(key string) bool {
+		var wasExpired bool
+
+		wasExpired, delerr = yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (bool, error) {
+			ent, err := cache.readE(tptoken, errstr, key)
+			if nil != err || !expired(ent, time.Now()) {
+				return false, err
+			}
+			return true, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, cache.Global, []string{key})
+		}, "", []string{})
+		if wasExpired {
+			deleted++
+		}
+		return nil == delerr
+	})
+	if nil != delerr {
+		return deleted, delerr
+	}
+	return deleted, err
+}
+
+// read is a method to read the entry with the given key in a transaction, so its value and expiry match
+func (cache *Cache) read(key string) (entry, error) {
+	return yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (entry, error) {
+		return cache.readE(tptoken, errstr, key)
+	}, "", []string{})
+}
+
+// readE is a method to read the entry with the given key
+func (cache *Cache) readE(tptoken uint64, errstr *yottadb.BufferT, key string) (entry, error) {
+	var ent entry
+
+	value, err := yottadb.ValE(tptoken, errstr, cache.Global, []string{key, "value"})
+	if nil != err {
+		if undefined(err) {
+			return ent, nil
+		}
+		return ent, err
+	}
+	ent.value, ent.found = value, true
+	value, err = yottadb.ValE(tptoken, errstr, cache.Global, []string{key, "expiry"})
+	if nil != err {
+		if undefined(err) {
+			return ent, nil
+		}
+		return ent, err
+	}
+	nsec, err := strconv.ParseInt(value, 10, 64)
+	if nil != err {
+		return ent, fmt.Errorf("cache: invalid expiry time %q for key %s", value, key)
+	}
+	ent.expiry = time.Unix(0, nsec)
+	return ent, nil
+}
+
+// expired is a function to return whether an entry has expired at now
+func expired(ent entry, now time.Time) bool {
+	return !ent.expiry.IsZero() && !now.Before(ent.expiry)
+}
+
+// undefined is a function to return whether err reports an undefined node
+func undefined(err error) bool {
+	return yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err)
+}
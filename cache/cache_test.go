@@ -0,0 +1,70 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package cache_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb/cache"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestCache(t *testing.T) {
+	c := cache.New(time.Hour)
+	defer c.Flush()
+	_, found, err := c.Get("missing")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	Assertnoerr(c.Set("default", []byte("d"), cache.DefaultExpiration), t)
+	Assertnoerr(c.Set("forever", []byte("f"), cache.NoExpiration), t)
+	Assertnoerr(c.Set("short", []byte("s"), 50*time.Millisecond), t)
+	value, found, err := c.Get("default")
+	Assertnoerr(err, t)
+	assert.True(t, found)
+	assert.Equal(t, []byte("d"), value)
+	ttl, found, err := c.TTL("default")
+	Assertnoerr(err, t)
+	assert.True(t, found)
+	assert.True(t, 59*time.Minute < ttl && ttl <= time.Hour)
+	ttl, found, err = c.TTL("forever")
+	Assertnoerr(err, t)
+	assert.True(t, found)
+	assert.Equal(t, cache.NoExpiration, ttl)
+	// Expired entries are not returned, and are deleted by DeleteExpired()
+	time.Sleep(100 * time.Millisecond)
+	_, found, err = c.Get("short")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	deleted, err := c.DeleteExpired()
+	Assertnoerr(err, t)
+	assert.Equal(t, 1, deleted)
+	// Setting an entry with NoExpiration removes its expiry
+	Assertnoerr(c.Set("default", []byte("d2"), cache.NoExpiration), t)
+	ttl, _, err = c.TTL("default")
+	Assertnoerr(err, t)
+	assert.Equal(t, cache.NoExpiration, ttl)
+	Assertnoerr(c.Delete("default"), t)
+	_, found, err = c.Get("default")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	Assertnoerr(c.Flush(), t)
+	_, found, err = c.Get("forever")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+}
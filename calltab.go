@@ -0,0 +1,293 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// RoutineParam is a structure describing a parameter of a call-in routine
+type RoutineParam struct {
+	Direction string // Direction the parameter is passed in - "I" (input), "O" (output) or "IO" (both)
+	Type      string // C type of the parameter with spaces removed (e.g. "ydb_string_t*")
+}
+
+// RoutineInfo is a structure describing a call-in routine, as defined by an entry in a call-in table
+type RoutineInfo struct {
+	Name       string         // Call-in name used to call the routine (e.g. with CallMT())
+	ReturnType string         // C type of the return value with spaces removed (e.g. "ydb_string_t*"), or "void"
+	EntryRef   string         // M entryref called (e.g. "entry^routine")
+	Params     []RoutineParam // Parameters of the routine
+}
+
+// Call-in table file names (or, for tables built with CallMTableBuilder, their []RoutineInfo), indexed by the handles of
+// the CallMTables opened for them
+var callMTableFiles sync.Map
+
+// C types for use with CallMTableBuilder. Only a few of the types YottaDB supports are listed - any type YottaDB supports
+// may be used. Note the wrapper passes all arguments as strings and requires output parameters to be *string, so
+// CallMString is the type to use for all output parameters.
+const (
+	CallMVoid   string = "void"          // No return value
+	CallMString string = "ydb_string_t*" // String (passed by reference)
+	CallMInt    string = "ydb_int_t"     // Integer (passed by value)
+	CallMLong   string = "ydb_long_t"    // Long integer (passed by value)
+	CallMDouble string = "ydb_double_t"  // Floating point number (passed by value)
+)
+
+// CallMTableBuilder is a structure for building a call-in table in Go rather than writing a call-in table file. The zero
+// value is an empty table ready for use. Routines are added with the chainable Func() method, e.g.
+//
+//	var builder yottadb.CallMTableBuilder
+//	builder.Func("add", yottadb.CallMString, "add^arith", yottadb.In(yottadb.CallMString), yottadb.In(yottadb.CallMString))
+//	calltab, err := builder.OpenT(yottadb.NOTTP, &errstr)
+type CallMTableBuilder struct {
+	routines []RoutineInfo
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Functions and methods to discover the routines in call-in tables
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// String is a method to return the description of a call-in routine in the format of a call-in table entry, e.g.
+// "HelloWorld2: ydb_string_t* entry^helloworld2(I:ydb_string_t*,IO:ydb_int_t*)".
+func (info RoutineInfo) String() string {
+	var desc strings.Builder
+
+	fmt.Fprintf(&desc, "%s: %s %s(", info.Name, info.ReturnType, info.EntryRef)
+	for i, param := range info.Params {
+		desc.WriteString(selectString(0 == i, "", ","))
+		desc.WriteString(param.Direction + ":" + param.Type)
+	}
+	desc.WriteByte(')')
+	return desc.String()
+}
+
+// ParseCallMTable is a function to parse a call-in table (see
+// https://docs.yottadb.com/ProgrammersGuide/extrout.html#calls-from-external-routines-call-ins) and return the routines it
+// defines, in the order they are defined. Blank lines and comments (starting with ";") are ignored. The tablename is only
+// used in error messages. If an entry is not valid, the INVCALLINTAB error is returned, giving the name of the table and
+// the line number of the entry.
+//
+// The parser checks the form of each entry, not that the types are ones YottaDB supports or that the M entryrefs exist.
+// YottaDB checks these when the table is opened or the routine is first called.
+func ParseCallMTable(reader io.Reader, tablename string) ([]RoutineInfo, error) {
+	var routines []RoutineInfo
+
+	scanner := bufio.NewScanner(reader)
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := scanner.Text()
+		if semi := strings.IndexByte(line, ';'); -1 != semi {
+			line = line[:semi]
+		}
+		if "" == strings.TrimSpace(line) {
+			continue
+		}
+		info, ok := parseCallMTableEntry(line)
+		if !ok {
+			where := tablename + ":" + strconv.Itoa(lineno) + ": " + strings.TrimSpace(line)
+			errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVCALLINTAB), "!AD", where, 1)
+			return nil, &YDBError{errcode: (int)(YDB_ERR_INVCALLINTAB), errmsg: errmsg}
+		}
+		routines = append(routines, info)
+	}
+	if err := scanner.Err(); nil != err {
+		return nil, err
+	}
+	return routines, nil
+}
+
+// Routines is a method to return the routines defined by the call-in table. The table file is read again on each call,
+// so the result reflects its current contents. For a table opened by CallMTableBuilder.OpenT(), the routines of the
+// builder are returned. For the default call-in table (the CallMTable returned by
+// CallMTableSwitchT() when switching from the default), the file named by the ydb_ci (or GTMCI) environment variable is
+// read.
+func (callmtab *CallMTable) Routines() ([]RoutineInfo, error) {
+	var tablename string
+
+	printEntry("CallMTable.Routines()")
+	if nil == callmtab {
+		panic("YDB: *CallMTable receiver of Routines() cannot be nil")
+	}
+	if table, ok := callMTableFiles.Load(callmtab.handle); ok {
+		if routines, ok := table.([]RoutineInfo); ok {
+			return append([]RoutineInfo(nil), routines...), nil
+		}
+		tablename = table.(string)
+	} else if 0 == callmtab.handle {
+		tablename = os.Getenv("ydb_ci")
+		if "" == tablename {
+			tablename = os.Getenv("GTMCI")
+		}
+	} else {
+		panic("YDB: CallMTable was not returned by CallMTableOpenT() or CallMTableSwitchT()")
+	}
+	file, err := os.Open(tablename)
+	if nil != err {
+		return nil, err
+	}
+	defer file.Close()
+	return ParseCallMTable(file, tablename)
+}
+
+// Describe is a method to return the description of the named routine in the call-in table, as returned by
+// RoutineInfo.String(). If the table does not define the routine, the CINOENTRY error is returned.
+func (callmtab *CallMTable) Describe(name string) (string, error) {
+	printEntry("CallMTable.Describe()")
+	routines, err := callmtab.Routines()
+	if nil != err {
+		return "", err
+	}
+	for _, info := range routines {
+		if name == info.Name {
+			return info.String(), nil
+		}
+	}
+	errmsg := "%YDB-E-CINOENTRY, No entry specified for " + name + " in the call-in table"
+	return "", &YDBError{errcode: (int)(YDB_ERR_CINOENTRY), errmsg: errmsg}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Functions and methods to build call-in tables
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// In is a function to return an input parameter of the given C type for CallMTableBuilder.Func().
+func In(paramType string) RoutineParam {
+	return RoutineParam{Direction: "I", Type: paramType}
+}
+
+// Out is a function to return an output parameter of the given C type for CallMTableBuilder.Func().
+func Out(paramType string) RoutineParam {
+	return RoutineParam{Direction: "O", Type: paramType}
+}
+
+// InOut is a function to return an input/output parameter of the given C type for CallMTableBuilder.Func().
+func InOut(paramType string) RoutineParam {
+	return RoutineParam{Direction: "IO", Type: paramType}
+}
+
+// Func is a method to add a routine to the call-in table, to be called by the given name, with the given return type
+// (CallMVoid if none) calling the given M entryref with the given parameters. It returns the builder so calls can be
+// chained. Entries are checked by OpenT().
+func (builder *CallMTableBuilder) Func(name, returnType, entryRef string, params ...RoutineParam) *CallMTableBuilder {
+	if nil == builder {
+		panic("YDB: *CallMTableBuilder receiver of Func() cannot be nil")
+	}
+	info := RoutineInfo{Name: name, ReturnType: returnType, EntryRef: entryRef}
+	info.Params = append(info.Params, params...)
+	builder.routines = append(builder.routines, info)
+	return builder
+}
+
+// String is a method to return the call-in table in the format of a call-in table file.
+func (builder *CallMTableBuilder) String() string {
+	var table strings.Builder
+
+	for _, info := range builder.routines {
+		table.WriteString(info.String())
+		table.WriteByte('\n')
+	}
+	return table.String()
+}
+
+// OpenT is a method to open the call-in table as CallMTableOpenT() opens a call-in table file, after checking each entry
+// as ParseCallMTable() does. The table is written to a temporary file for YottaDB to read, which is removed once the
+// table is open. Routines() on the returned CallMTable returns the routines of the builder.
+func (builder *CallMTableBuilder) OpenT(tptoken uint64, errstr *BufferT) (*CallMTable, error) {
+	printEntry("CallMTableBuilder.OpenT()")
+	if nil == builder {
+		panic("YDB: *CallMTableBuilder receiver of OpenT() cannot be nil")
+	}
+	table := builder.String()
+	routines, err := ParseCallMTable(strings.NewReader(table), "CallMTableBuilder")
+	if nil != err {
+		return nil, err
+	}
+	file, err := os.CreateTemp("", "ydbgo*.ci")
+	if nil != err {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.WriteString(table)
+	if closeErr := file.Close(); nil == err {
+		err = closeErr
+	}
+	if nil != err {
+		return nil, err
+	}
+	callmtab, err := CallMTableOpenT(tptoken, errstr, file.Name())
+	if nil != err {
+		return nil, err
+	}
+	callMTableFiles.Store(callmtab.handle, routines) // The file is removed so remember the routines themselves
+	return callmtab, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// parseCallMTableEntry is a function to parse a call-in table entry of the form
+// "name: return-type entryref(direction:type,...)". It returns false if the entry is not of that form.
+func parseCallMTableEntry(line string) (RoutineInfo, bool) {
+	var info RoutineInfo
+
+	colon := strings.IndexByte(line, ':')
+	if -1 == colon {
+		return info, false
+	}
+	info.Name = strings.TrimSpace(line[:colon])
+	rest := line[colon+1:]
+	params := ""
+	if open := strings.IndexByte(rest, '('); -1 != open {
+		if !strings.HasSuffix(strings.TrimSpace(rest), ")") {
+			return info, false
+		}
+		params = strings.TrimSpace(rest[open+1 : strings.LastIndexByte(rest, ')')])
+		rest = rest[:open]
+	}
+	fields := strings.Fields(rest)
+	if "" == info.Name || strings.ContainsAny(info.Name, " \t") || 2 > len(fields) {
+		return info, false
+	}
+	info.EntryRef = fields[len(fields)-1]
+	info.ReturnType = strings.Join(fields[:len(fields)-1], "")
+	if "" == params {
+		return info, true
+	}
+	for _, param := range strings.Split(params, ",") {
+		colon = strings.IndexByte(param, ':')
+		if -1 == colon {
+			return info, false
+		}
+		direction := strings.ToUpper(strings.TrimSpace(param[:colon]))
+		paramType := strings.Join(strings.Fields(param[colon+1:]), "")
+		if ("I" != direction && "O" != direction && "IO" != direction) || "" == paramType {
+			return info, false
+		}
+		info.Params = append(info.Params, RoutineParam{Direction: direction, Type: paramType})
+	}
+	return info, true
+}
@@ -0,0 +1,91 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"strings"
+	"testing"
+)
+
+func TestParseCallMTable(t *testing.T) {
+	table := `; Routines for the tests
+HelloWorld2 : ydb_string_t * entry^helloworld2(I:ydb_string_t *, io:ydb_int_t*)  ; Trailing comment
+
+Run: void run^routine()
+`
+	routines, err := yottadb.ParseCallMTable(strings.NewReader(table), "test.ci")
+	Assertnoerr(err, t)
+	assert.Equal(t, []yottadb.RoutineInfo{
+		{Name: "HelloWorld2", ReturnType: "ydb_string_t*", EntryRef: "entry^helloworld2",
+			Params: []yottadb.RoutineParam{{Direction: "I", Type: "ydb_string_t*"}, {Direction: "IO", Type: "ydb_int_t*"}}},
+		{Name: "Run", ReturnType: "void", EntryRef: "run^routine"},
+	}, routines)
+	assert.Equal(t, "HelloWorld2: ydb_string_t* entry^helloworld2(I:ydb_string_t*,IO:ydb_int_t*)", routines[0].String())
+	for _, bad := range []string{"NoColon void run^x()", "Name: run^x()", "Name: void run^x(X:ydb_int_t)",
+		"Name: void run^x(I:ydb_int_t"} {
+		_, err = yottadb.ParseCallMTable(strings.NewReader("Good: void run^x()\n"+bad), "bad.ci")
+		assert.Equal(t, yottadb.YDB_ERR_INVCALLINTAB, yottadb.ErrorCode(err), bad)
+		assert.Contains(t, err.Error(), "bad.ci:2: ")
+	}
+}
+
+func TestCallMTableRoutines(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	calltab, err := yottadb.CallMTableOpenT(yottadb.NOTTP, &errstr, "calltab.ci")
+	Assertnoerr(err, t)
+	routines, err := calltab.Routines()
+	Assertnoerr(err, t)
+	assert.Equal(t, 7, len(routines))
+	desc, err := calltab.Describe("HelloWorld2")
+	Assertnoerr(err, t)
+	assert.Equal(t, "HelloWorld2: ydb_string_t* entry^helloworld2(I:ydb_string_t*,I:ydb_string_t*,I:ydb_string_t*)", desc)
+	_, err = calltab.Describe("NoSuchRoutine")
+	assert.Equal(t, yottadb.YDB_ERR_CINOENTRY, yottadb.ErrorCode(err))
+}
+
+func TestCallMTableBuilder(t *testing.T) {
+	var errstr yottadb.BufferT
+	var builder yottadb.CallMTableBuilder
+
+	envvarSave := make(map[string]string)
+	saveEnvvars(t, &envvarSave, "ydb_routines")
+	includeInEnvvar(t, "ydb_routines", "./m_routines")
+	defer restoreEnvvars(t, &envvarSave, "ydb_routines")
+	errstr.Alloc(128)
+	defer errstr.Free()
+	builder.Func("BuiltArgs", yottadb.CallMString, "entry^callmargs", yottadb.In(yottadb.CallMString),
+		yottadb.In(yottadb.CallMString))
+	assert.Equal(t, "BuiltArgs: ydb_string_t* entry^callmargs(I:ydb_string_t*,I:ydb_string_t*)\n", builder.String())
+	calltab, err := builder.OpenT(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	routines, err := calltab.Routines()
+	Assertnoerr(err, t)
+	assert.Equal(t, 1, len(routines))
+	assert.Equal(t, "entry^callmargs", routines[0].EntryRef)
+	prevtab, err := calltab.CallMTableSwitchT(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	defer prevtab.CallMTableSwitchT(yottadb.NOTTP, &errstr)
+	retval, err := yottadb.CallMT(yottadb.NOTTP, &errstr, 64, "BuiltArgs", "abc", "undefined")
+	Assertnoerr(err, t)
+	assert.Equal(t, "3:99:", retval)
+	var bad yottadb.CallMTableBuilder
+	_, err = bad.Func("Bad", yottadb.CallMVoid, "run^x", yottadb.RoutineParam{Direction: "X", Type: "ydb_int_t"}).
+		OpenT(yottadb.NOTTP, &errstr)
+	assert.Equal(t, yottadb.YDB_ERR_INVCALLINTAB, yottadb.ErrorCode(err))
+}
@@ -0,0 +1,112 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package chaos injects random faults into the YottaDB operations of a process, to check that an application copes with the
+errors and delays it may meet in production: transaction restarts, lock timeouts, full lock space, slow operations and
+so on.
+
+Enable() installs a hook with yottadb.SetFaultHook() that, for each operation selected by the Policy, returns one of the
+policy's errors with the policy's probability and sleeps for a random time up to the policy's maximum latency. For
+example, to restart one in ten transactions at some operation and slow down some reads:
+
+	chaos.Enable(&chaos.Policy{
+		ErrorRate:  0.1,
+		Errors:     []int{yottadb.YDB_TP_RESTART},
+		MaxLatency: 5 * time.Millisecond,
+		Ops:        []string{"Get", "Set"},
+	})
+	defer chaos.Disable()
+
+YDB_TP_RESTART is only injected within transactions, where it restarts the transaction as a conflict with another process
+would. Injected errors are built with yottadb.NewError(), so they carry the same codes and messages as errors from
+YottaDB.
+*/
+package chaos
+
+import (
+	"lang.yottadb.com/go/yottadb"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Policy describes the faults injected by Enable()
+type Policy struct {
+	ErrorRate   float64       // Probability (0 to 1) of an operation returning one of Errors
+	Errors      []int         // Error codes to choose from, e.g. yottadb.YDB_TP_RESTART or yottadb.YDB_LOCK_TIMEOUT
+	LatencyRate float64       // Probability (0 to 1) of an operation being delayed, or 1 if 0 and MaxLatency is set
+	MaxLatency  time.Duration // Maximum delay added to an operation, chosen uniformly at random
+	Ops         []string      // Operations faults are injected into (see yottadb.TraceOps()), or all if empty
+	Prefix      string        // If not empty, only nodes whose ZWRITE name starts with Prefix, e.g. "^orders(", are affected
+	Seed        int64         // Seed of the random choices, for repeatable runs, or 0 to seed from the time
+}
+
+var rngMutex sync.Mutex // Protects the random number generator of the enabled policy, which is not safe for concurrent use
+
+// Enable is a function to start injecting the faults described by policy into the database operations of the process,
+// replacing any hook set with yottadb.SetFaultHook()
+func Enable(policy *Policy) {
+	pol := *policy
+	pol.Errors = append([]int(nil), policy.Errors...)
+	pol.Ops = append([]string(nil), policy.Ops...)
+	if 0 == pol.LatencyRate && 0 < pol.MaxLatency {
+		pol.LatencyRate = 1
+	}
+	seed := pol.Seed
+	if 0 == seed {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	yottadb.SetFaultHook(func(tptoken uint64, op, node string) error {
+		if !pol.selects(op, node) {
+			return nil
+		}
+		rngMutex.Lock()
+		delay := time.Duration(0)
+		if 0 < pol.MaxLatency && rng.Float64() < pol.LatencyRate {
+			delay = time.Duration(rng.Int63n(int64(pol.MaxLatency) + 1))
+		}
+		code := 0
+		if 0 < len(pol.Errors) && rng.Float64() < pol.ErrorRate {
+			code = pol.Errors[rng.Intn(len(pol.Errors))]
+		}
+		rngMutex.Unlock()
+		time.Sleep(delay)
+		if 0 == code || (yottadb.YDB_TP_RESTART == code && yottadb.NOTTP == tptoken) {
+			return nil
+		}
+		return yottadb.NewError(tptoken, nil, code)
+	})
+}
+
+// Disable is a function to stop injecting faults, removing any hook set with yottadb.SetFaultHook()
+func Disable() {
+	yottadb.SetFaultHook(nil)
+}
+
+// selects is a method to return whether the policy applies to the given operation on the named node
+func (pol *Policy) selects(op, node string) bool {
+	if !strings.HasPrefix(node, pol.Prefix) {
+		return false
+	}
+	if 0 == len(pol.Ops) {
+		return true
+	}
+	for _, polop := range pol.Ops {
+		if op == polop {
+			return true
+		}
+	}
+	return false
+}
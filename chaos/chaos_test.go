@@ -0,0 +1,73 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package chaos_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"lang.yottadb.com/go/yottadb/chaos"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestRestartsAreRetried(t *testing.T) {
+	chaos.Enable(&chaos.Policy{ErrorRate: 0.5, Errors: []int{yottadb.YDB_TP_RESTART}, Ops: []string{"Set"}, Prefix: "^chaos(", Seed: 1})
+	defer chaos.Disable()
+	// YottaDB runs the transaction again after each injected restart, until it commits
+	err := yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		if err := yottadb.SetValE(tptoken, errstr, "done", "^chaos", []string{"tp"}); nil != err {
+			return int32(yottadb.ErrorCode(err))
+		}
+		return yottadb.YDB_OK
+	}, "BATCH", []string{})
+	Assertnoerr(err, t)
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^chaos", []string{"tp"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "done", val)
+	// Outside a transaction, restarts are not injected
+	for i := 0; i < 20; i++ {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "done", "^chaos", []string{"nottp"}), t)
+	}
+}
+
+func TestErrorRateAndFilter(t *testing.T) {
+	var failed int
+
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "value", "^chaos", []string{"rate"}), t)
+	// An injected INVSTRLEN reports a longer value each time, so ValE() reallocates its buffer a bounded number of times
+	// and then returns the error
+	chaos.Enable(&chaos.Policy{ErrorRate: 1, Errors: []int{yottadb.YDB_ERR_INVSTRLEN}, Ops: []string{"Get"}, Prefix: "^chaos("})
+	defer chaos.Disable()
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "value", "^chaosother", []string{}), t)
+	for i := 0; i < 10; i++ {
+		if _, err := yottadb.ValE(yottadb.NOTTP, nil, "^chaos", []string{"rate"}); nil != err {
+			assert.Equal(t, yottadb.YDB_ERR_INVSTRLEN, yottadb.ErrorCode(err))
+			failed++
+		}
+		_, err := yottadb.ValE(yottadb.NOTTP, nil, "^chaosother", []string{})
+		Assertnoerr(err, t)
+	}
+	assert.Equal(t, 10, failed)
+	// The same holds for the subscripts of NodeNextE()
+	chaos.Enable(&chaos.Policy{ErrorRate: 1, Errors: []int{yottadb.YDB_ERR_INVSTRLEN, yottadb.YDB_ERR_INSUFFSUBS},
+		Ops: []string{"NodeNext"}, Prefix: "^chaos"})
+	for i := 0; i < 10; i++ {
+		_, err := yottadb.NodeNextE(yottadb.NOTTP, nil, "^chaos", []string{})
+		assert.Contains(t, []int{yottadb.YDB_ERR_INVSTRLEN, yottadb.YDB_ERR_INSUFFSUBS}, yottadb.ErrorCode(err))
+	}
+}
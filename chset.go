@@ -0,0 +1,102 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Values of $ZCHSET returned by ChSetE()
+const (
+	ChSetM    string = "M"     // Strings are sequences of bytes
+	ChSetUTF8 string = "UTF-8" // Strings are sequences of UTF-8 encoded characters
+)
+
+var ydbChSet string          // Cached value of $ZCHSET, which cannot change once the engine is initialized
+var ydbChSetMutex sync.Mutex // Mutex for access to ydbChSet
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Functions for dealing with the character set (M or UTF-8 mode) YottaDB runs in
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ChSetE is an Easy API function to return the character set YottaDB is running with - ChSetM if the engine treats strings
+// as sequences of bytes (M mode) or ChSetUTF8 if it treats them as UTF-8 encoded characters (UTF-8 mode). The mode is set
+// by the ydb_chset environment variable when the engine is initialized and cannot change afterwards, so the value of
+// $ZCHSET is only fetched on the first call.
+//
+// The mode affects how the engine interprets values in M code and string functions. In UTF-8 mode, for example, a value
+// that is not valid UTF-8 causes a BADCHAR error when M code operates on it, so it can be useful to validate values with
+// ValidateChSetE() before storing them.
+func ChSetE(tptoken uint64, errstr *BufferT) (string, error) {
+	printEntry("ChSetE()")
+	ydbChSetMutex.Lock()
+	defer ydbChSetMutex.Unlock()
+	if "" == ydbChSet {
+		chset, err := ValE(tptoken, errstr, "$ZCHSET", []string{})
+		if nil != err {
+			return "", err
+		}
+		ydbChSet = chset
+	}
+	return ydbChSet, nil
+}
+
+// ValidateChSetE is an Easy API function to verify a value or subscript is valid for the character set YottaDB is running
+// with. In M mode, any string is valid. In UTF-8 mode, a string that is not valid UTF-8 returns the INVUTF8STR error,
+// rather than the value causing a BADCHAR error later when M code operates on it. ReplaceInvalidUTF8() or Latin1ToUTF8()
+// can be used to transform such a string into valid UTF-8.
+//
+// If fetching the character set fails, the function returns that error.
+func ValidateChSetE(tptoken uint64, errstr *BufferT, value string) error {
+	printEntry("ValidateChSetE()")
+	chset, err := ChSetE(tptoken, errstr)
+	if nil != err {
+		return err
+	}
+	if ChSetUTF8 == chset && !utf8.ValidString(value) {
+		errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVUTF8STR), "!AD", Quote(value), 1)
+		return &YDBError{errcode: (int)(YDB_ERR_INVUTF8STR), errmsg: errmsg}
+	}
+	return nil
+}
+
+// ReplaceInvalidUTF8 is a function to return a copy of the given string with each run of bytes that are not valid UTF-8
+// replaced by the replacement string, which may be empty to drop those bytes. A replacement of "�" (the Unicode
+// replacement character) is conventional.
+func ReplaceInvalidUTF8(value, replacement string) string {
+	return strings.ToValidUTF8(value, replacement)
+}
+
+// Latin1ToUTF8 is a function to transcode a string of bytes in the ISO-8859-1 (Latin-1) character set, as commonly stored
+// by applications running YottaDB in M mode, into UTF-8. Each byte becomes the character with the same code point, so ASCII
+// strings are returned unchanged.
+func Latin1ToUTF8(value string) string {
+	var i int
+	var str strings.Builder
+
+	for i = 0; i < len(value) && utf8.RuneSelf > value[i]; i++ {
+	}
+	if len(value) == i {
+		return value // Pure ASCII - nothing to transcode
+	}
+	str.Grow(len(value) + len(value) - i)
+	str.WriteString(value[:i])
+	for ; i < len(value); i++ {
+		str.WriteRune(rune(value[i]))
+	}
+	return str.String()
+}
@@ -0,0 +1,52 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestChSetE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	chset, err := yottadb.ChSetE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Contains(t, []string{yottadb.ChSetM, yottadb.ChSetUTF8}, chset)
+	// A second call returns the cached value
+	again, err := yottadb.ChSetE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, chset, again)
+	// Valid UTF-8 is valid in either mode but invalid UTF-8 is only valid in M mode
+	Assertnoerr(yottadb.ValidateChSetE(yottadb.NOTTP, &errstr, "héllo"), t)
+	err = yottadb.ValidateChSetE(yottadb.NOTTP, &errstr, "h\xe9llo")
+	if yottadb.ChSetUTF8 == chset {
+		assert.Equal(t, yottadb.YDB_ERR_INVUTF8STR, yottadb.ErrorCode(err))
+		assert.Contains(t, err.Error(), `"h"_$ZCH(233)_"llo"`)
+	} else {
+		Assertnoerr(err, t)
+	}
+}
+
+func TestUTF8Helpers(t *testing.T) {
+	assert.Equal(t, "h�llo", yottadb.ReplaceInvalidUTF8("h\xe9llo", "�"))
+	assert.Equal(t, "hllo", yottadb.ReplaceInvalidUTF8("h\xe9\xe8llo", ""))
+	assert.Equal(t, "héllo", yottadb.ReplaceInvalidUTF8("héllo", "?"))
+	assert.Equal(t, "héllo", yottadb.Latin1ToUTF8("h\xe9llo"))
+	assert.Equal(t, "hello", yottadb.Latin1ToUTF8("hello"))
+	assert.Equal(t, "ÿ", yottadb.Latin1ToUTF8("\xff"))
+}
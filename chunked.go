@@ -0,0 +1,88 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"strconv"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API function to apply a function to items in a series of transactions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ChunkedTpE is an Easy API function to call fn for each of items in a series of transactions of at most chunkSize items
+// each, for bulk updates too large for one transaction, and return the number of items applied.
+//
+// Each transaction calls fn for its items in order and commits their updates together; fn is called as a TpValE() closure,
+// so it may be called more than once for the same item if the transaction restarts, and an error returned by fn rolls back
+// the updates of the chunk and stops ChunkedTpE(). The items of the chunks that committed stay applied, and the number
+// returned along with the error is the number of items applied, so the caller can resume with the items after those.
+//
+// If varname is not empty, varname(subary...) records the progress across runs: each transaction also sets the node to the
+// number of items applied, ChunkedTpE() skips that many items at its start, and the node is deleted once all items are
+// applied. A job interrupted by an error or a crash then resumes where it stopped when it runs again with the same items and
+// node. The number returned includes the items applied by earlier runs.
+//
+// If the progress node holds anything other than a number of items up to len(items), ChunkedTpE() returns the INVCHUNKPROG
+// error without applying any items. ChunkedTpE() panics if chunkSize is not positive. Called within a transaction, the
+// chunks are nested transactions that commit only with the enclosing transaction.
+func ChunkedTpE[T any](tptoken uint64, errstr *BufferT, items []T, chunkSize int,
+	fn func(tptoken uint64, errstr *BufferT, item T) error, varname string, subary []string) (int, error) {
+	var done int
+
+	printEntry("ChunkedTpE()")
+	if 0 >= chunkSize {
+		panic("YDB: chunkSize of ChunkedTpE() must be positive")
+	}
+	if "" != varname {
+		value, err := ValE(tptoken, errstr, varname, subary)
+		if nil == err {
+			if done, err = strconv.Atoi(value); nil != err || 0 > done || len(items) < done {
+				errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVCHUNKPROG), "!AD", value, 1)
+				return 0, &YDBError{errcode: (int)(YDB_ERR_INVCHUNKPROG), errmsg: errmsg}
+			}
+		} else if errcode := ErrorCode(err); YDB_ERR_GVUNDEF != errcode && YDB_ERR_LVUNDEF != errcode {
+			return 0, err
+		}
+	}
+	for done < len(items) {
+		end := done + chunkSize
+		if len(items) < end {
+			end = len(items)
+		}
+		_, err := TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (struct{}, error) {
+			for _, item := range items[done:end] {
+				if err := fn(tptoken, errstr, item); nil != err {
+					return struct{}{}, err
+				}
+			}
+			if "" != varname {
+				return struct{}{}, SetValE(tptoken, errstr, strconv.Itoa(end), varname, subary)
+			}
+			return struct{}{}, nil
+		}, "", []string{})
+		if nil != err {
+			return done, err
+		}
+		done = end
+	}
+	if "" != varname {
+		if err := DeleteE(tptoken, errstr, YDB_DEL_NODE, varname, subary); nil != err {
+			return done, err
+		}
+	}
+	return done, nil
+}
@@ -0,0 +1,78 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"strconv"
+	"testing"
+)
+
+func TestChunkedTpE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^chunked", []string{})
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+	failAt := 6
+	errFail := errors.New("item failed")
+	set := func(tptoken uint64, errstr *yottadb.BufferT, item int) error {
+		if item == failAt {
+			return errFail
+		}
+		return yottadb.SetValE(tptoken, errstr, "done", "^chunked", []string{"item", strconv.Itoa(item)})
+	}
+	// The chunk holding the failing item is rolled back and the progress node records the chunks committed
+	done, err := yottadb.ChunkedTpE(yottadb.NOTTP, &errstr, items, 3, set, "^chunked", []string{"progress"})
+	assert.Equal(t, errFail, err)
+	assert.Equal(t, 3, done)
+	value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^chunked", []string{"progress"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "3", value)
+	data, err := yottadb.DataE(yottadb.NOTTP, &errstr, "^chunked", []string{"item", "4"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+	// Running again resumes after the items applied and deletes the progress node when done
+	failAt = 0
+	var calls []int
+	done, err = yottadb.ChunkedTpE(yottadb.NOTTP, &errstr, items, 3, func(tptoken uint64, errstr *yottadb.BufferT,
+		item int) error {
+		calls = append(calls, item)
+		return set(tptoken, errstr, item)
+	}, "^chunked", []string{"progress"})
+	Assertnoerr(err, t)
+	assert.Equal(t, 7, done)
+	assert.Equal(t, []int{4, 5, 6, 7}, calls)
+	data, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^chunked", []string{"progress"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+	for _, item := range items {
+		data, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^chunked", []string{"item", strconv.Itoa(item)})
+		Assertnoerr(err, t)
+		assert.Equal(t, uint32(1), data)
+	}
+	// Without a progress node, every item is applied
+	done, err = yottadb.ChunkedTpE(yottadb.NOTTP, &errstr, items, 100, set, "", nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, 7, done)
+	assert.Panics(t, func() { yottadb.ChunkedTpE(yottadb.NOTTP, &errstr, items, 0, set, "", nil) })
+	// A progress node that does not hold a number of items is an error
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "8", "^chunked", []string{"progress"}), t)
+	done, err = yottadb.ChunkedTpE(yottadb.NOTTP, &errstr, items, 3, set, "^chunked", []string{"progress"})
+	assert.Equal(t, yottadb.YDB_ERR_INVCHUNKPROG, yottadb.ErrorCode(err))
+	assert.Equal(t, 0, done)
+}
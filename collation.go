@@ -0,0 +1,316 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Limits on the numbers YottaDB can represent - a canonical number must fall within these to be treated as numeric.
+const (
+	ydbMaxNumericExponent int = 47 // Numbers must be less than 1E47
+	ydbMinNumericExponent int = 43 // Non-zero numbers must be at least 1E-43
+)
+
+// Classes of subscripts in the order YottaDB collates them
+const (
+	subClassNull int = iota
+	subClassNumeric
+	subClassString
+)
+
+var collationMap map[int]func(a, b string) int // Comparators for alternate collation sequences, indexed by collation number
+var collationMapMutex sync.Mutex               // Mutex for access to collationMap
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Functions for predicting how YottaDB collates subscripts
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// IsCanonicalNumber is a function to determine whether YottaDB would treat the given string as a number when used as a
+// subscript. YottaDB stores a subscript as a number, and collates it before all string subscripts in numeric order, only
+// when it is in canonical form - the form M itself would produce when converting that number to a string.
+//
+// A canonical number has an optional leading minus sign (but is not -0), no leading zeros in its integer part (so 0.5
+// is written .5), no trailing zeros in its fractional part, no trailing decimal point, no exponent, and no more than 18
+// significant digits. Thus "12", "-1.5" and ".25" are canonical numbers while "012", "1.50", "1.", "+1" and "1E3" are
+// strings.
+func IsCanonicalNumber(s string) bool {
+	if "0" == s {
+		return true
+	}
+	if strings.HasPrefix(s, "-") {
+		s = s[1:]
+	}
+	intpart, fracpart, hasdot := strings.Cut(s, ".")
+	if hasdot && ("" == fracpart || '0' == fracpart[len(fracpart)-1]) {
+		return false // Trailing decimal point or trailing zero in fraction
+	}
+	if "" == intpart {
+		if !hasdot {
+			return false // Empty string or a lone minus sign
+		}
+	} else if '0' == intpart[0] {
+		return false // Leading zero (including "-0" and "0.5")
+	}
+	if !isAllDigits(intpart) || !isAllDigits(fracpart) {
+		return false
+	}
+	// Count the significant digits and verify both their count and the magnitude of the number are in range
+	var digits int
+	if "" != intpart {
+		if len(intpart) > ydbMaxNumericExponent {
+			return false
+		}
+		digits = len(intpart) + len(fracpart)
+		if "" == fracpart {
+			digits = len(strings.TrimRight(intpart, "0")) // Trailing zeros of an integer are not significant
+		}
+	} else {
+		digits = len(strings.TrimLeft(fracpart, "0"))
+		if len(fracpart)-digits >= ydbMinNumericExponent {
+			return false
+		}
+	}
+	return digits <= ydbMaxNumericDigits
+}
+
+// CanonicalizeSubscript is a function to return the string form YottaDB would use for a Go value used as a subscript.
+// Integer, floating point and big.Rat values are formatted as canonical numbers so they collate numerically (e.g. the
+// float64 value 0.50 becomes ".5"). Strings and byte slices are used as-is, so a string such as "1.50" remains a string
+// subscript and collates after all numeric subscripts. Values of other types are formatted with fmt.Sprintf("%v").
+//
+// Note that YottaDB collates a number numerically only if it has no more than 18 significant digits and is less than 1E47
+// and, if not zero, at least 1E-43 in magnitude (see IsCanonicalNumber()). Integers and floating point values are formatted
+// exactly, so those outside these limits - such as a uint64 greater than 999999999999999999 or the float64 value 1e300 - are
+// not canonical numbers and collate as strings, after all numeric subscripts. A big.Rat with no exact decimal form (such
+// as 1/3) is rounded to 18 decimal places, so it is not its exact value, and collates as a string if the rounded value has
+// more than 18 significant digits (such as 10/3). Callers needing numeric collation should check the result with
+// IsCanonicalNumber(). Floating point values that are not finite (NaN and infinities) cannot be represented as numbers by
+// YottaDB and are formatted as strings.
+func CanonicalizeSubscript(v interface{}) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case []byte:
+		return string(val)
+	case int:
+		return strconv.FormatInt(int64(val), 10)
+	case int8:
+		return strconv.FormatInt(int64(val), 10)
+	case int16:
+		return strconv.FormatInt(int64(val), 10)
+	case int32:
+		return strconv.FormatInt(int64(val), 10)
+	case int64:
+		return strconv.FormatInt(val, 10)
+	case uint:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint8:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint16:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint32:
+		return strconv.FormatUint(uint64(val), 10)
+	case uint64:
+		return strconv.FormatUint(val, 10)
+	case float32:
+		return canonicalDecimal(strconv.FormatFloat(float64(val), 'f', -1, 32))
+	case float64:
+		return canonicalDecimal(strconv.FormatFloat(val, 'f', -1, 64))
+	case *big.Rat:
+		return canonicalDecimal(decimalToM(val))
+	case *big.Int:
+		return val.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// TypedSubscript is a function to return a subscript as a Go value of the type YottaDB treats it as, the inverse of
+// CanonicalizeSubscript(). A canonical number (see IsCanonicalNumber()) is returned as an int64 if it is an integer in
+// the range of int64 and otherwise as a float64, which may round numbers with more than 15 significant digits. Any other
+// subscript, including the empty subscript and strings such as "1.50" that look like numbers, is returned as a string.
+func TypedSubscript(sub string) interface{} {
+	if !IsCanonicalNumber(sub) {
+		return sub
+	}
+	if !strings.Contains(sub, ".") {
+		if val, err := strconv.ParseInt(sub, 10, 64); nil == err {
+			return val
+		}
+	}
+	val, _ := strconv.ParseFloat(sub, 64) // Canonical numbers are within the range of float64
+	return val
+}
+
+// TypedSubscripts is a function to return a slice of subscripts, such as those returned by NodeNextE(), converted to Go
+// values by TypedSubscript(), so that numeric subscripts need not be parsed again by the caller.
+func TypedSubscripts(subary []string) []interface{} {
+	typed := make([]interface{}, len(subary))
+	for i, sub := range subary {
+		typed[i] = TypedSubscript(sub)
+	}
+	return typed
+}
+
+// CompareSubscripts is a function to compare two subscripts the way the M standard collation sequence (collation 0, the
+// default) orders them. It returns -1 if a collates before b, 0 if they are the same subscript and +1 if a collates after
+// b. The empty (null) subscript collates first, followed by canonical numbers (see IsCanonicalNumber()) in numeric order,
+// followed by all other strings in byte order. Client-side sorts and merges that use this comparison agree with the order
+// in which SubNextE() and SubPrevE() return subscripts.
+func CompareSubscripts(a, b string) int {
+	return compareSubscripts(a, b, strings.Compare)
+}
+
+// SortSubscripts is a function to sort a slice of subscripts in place into M standard collation order as defined by
+// CompareSubscripts().
+func SortSubscripts(subs []string) {
+	sort.SliceStable(subs, func(i, j int) bool { return 0 > CompareSubscripts(subs[i], subs[j]) })
+}
+
+// RegisterCollation is a function to register a Go comparison function for the alternate collation sequence with the given
+// number, so client-side ordering can agree with that of globals and locals using an alternate collation module. Alternate
+// collation modules only transform string subscripts - null and numeric subscripts still collate first - so cmp is only
+// called to compare two non-numeric, non-null subscripts and must return a negative number, 0 or a positive number as a
+// collates before, the same as or after b. A nil cmp removes any registration for the collation number.
+//
+// The collation number must be in the range 1-255 (collation 0 is the M standard collation and cannot be replaced). The
+// collation in effect for a global is set by the database administrator (see %GBLDEF in the YottaDB Programmer's Guide)
+// and must be known to the application.
+func RegisterCollation(collation int, cmp func(a, b string) int) {
+	printEntry("RegisterCollation()")
+	if (1 > collation) || (255 < collation) {
+		panic(fmt.Sprintf("YDB: Collation number %d is not in the range 1-255", collation))
+	}
+	collationMapMutex.Lock()
+	defer collationMapMutex.Unlock()
+	if nil == cmp {
+		delete(collationMap, collation)
+		return
+	}
+	if nil == collationMap {
+		collationMap = make(map[int]func(a, b string) int)
+	}
+	collationMap[collation] = cmp
+}
+
+// CollationComparator is a function to return a comparison function ordering subscripts the way the given collation
+// sequence does. Collation 0 returns CompareSubscripts(). Other collation numbers return a comparison that orders null and
+// numeric subscripts as CompareSubscripts() does and uses the function registered with RegisterCollation() for string
+// subscripts. If no function is registered for the collation number, nil is returned.
+func CollationComparator(collation int) func(a, b string) int {
+	if 0 == collation {
+		return CompareSubscripts
+	}
+	collationMapMutex.Lock()
+	cmp, ok := collationMap[collation]
+	collationMapMutex.Unlock()
+	if !ok {
+		return nil
+	}
+	return func(a, b string) int {
+		return compareSubscripts(a, b, cmp)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// canonicalDecimal is a function to convert a plain decimal string (optional minus sign, digits and an optional decimal
+// point - no exponent) into M canonical form by removing leading zeros from the integer part and trailing zeros from the
+// fractional part. Strings not in this plain form (e.g. "NaN" or "+Inf") are returned unchanged.
+func canonicalDecimal(s string) string {
+	negative := strings.HasPrefix(s, "-")
+	if negative {
+		s = s[1:]
+	}
+	intpart, fracpart, hasdot := strings.Cut(s, ".")
+	if ("" == intpart && "" == fracpart) || !isAllDigits(intpart) || !isAllDigits(fracpart) {
+		return selectString(negative, "-", "") + s
+	}
+	intpart = strings.TrimLeft(intpart, "0")
+	if hasdot {
+		fracpart = strings.TrimRight(fracpart, "0")
+	}
+	if "" == intpart && "" == fracpart {
+		return "0"
+	}
+	retval := intpart
+	if "" != fracpart {
+		retval = retval + "." + fracpart
+	}
+	if negative {
+		retval = "-" + retval
+	}
+	return retval
+}
+
+// compareSubscripts is a function to compare two subscripts by class (null, then numeric, then string), comparing numeric
+// subscripts by value and using the supplied function to compare string subscripts. The result is normalized to -1, 0 or 1.
+func compareSubscripts(a, b string, cmpStrings func(a, b string) int) int {
+	var retval int
+
+	aclass := subscriptClass(a)
+	bclass := subscriptClass(b)
+	switch {
+	case aclass != bclass:
+		retval = aclass - bclass
+	case subClassNull == aclass:
+		retval = 0
+	case subClassNumeric == aclass:
+		anum, _ := new(big.Rat).SetString(a)
+		bnum, _ := new(big.Rat).SetString(b)
+		retval = anum.Cmp(bnum)
+	default:
+		retval = cmpStrings(a, b)
+	}
+	switch {
+	case 0 > retval:
+		return -1
+	case 0 < retval:
+		return 1
+	}
+	return 0
+}
+
+// subscriptClass is a function to return the collation class (subClassNull, subClassNumeric or subClassString) of a subscript.
+func subscriptClass(sub string) int {
+	if "" == sub {
+		return subClassNull
+	}
+	if IsCanonicalNumber(sub) {
+		return subClassNumeric
+	}
+	return subClassString
+}
+
+// isAllDigits is a function to determine whether a string is made up only of the decimal digits 0-9. The empty string
+// is considered all digits.
+func isAllDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if '0' > s[i] || '9' < s[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestIsCanonicalNumber(t *testing.T) {
+	canonical := []string{"0", "1", "-1", "12", "1.5", "-1.5", ".25", "-.25", "100", "123456789012345678",
+		"1" + strings.Repeat("0", 46), "." + strings.Repeat("0", 42) + "1", "1234567890.12345678"}
+	for _, s := range canonical {
+		assert.True(t, yottadb.IsCanonicalNumber(s), "%q should be canonical", s)
+	}
+	notCanonical := []string{"", "-", "-0", "00", "012", "0.5", "-0.5", "1.50", "1.", ".", "+1", "1E3", "1e3", " 1",
+		"1 ", "abc", "1a", "--1", "1.2.3", "1234567890123456789", "1" + strings.Repeat("0", 47),
+		"." + strings.Repeat("0", 43) + "1", "1234567890.123456789"}
+	for _, s := range notCanonical {
+		assert.False(t, yottadb.IsCanonicalNumber(s), "%q should not be canonical", s)
+	}
+}
+
+func TestCanonicalizeSubscript(t *testing.T) {
+	type testcase struct {
+		value    interface{}
+		expected string
+	}
+	tests := []testcase{
+		{"1.50", "1.50"},
+		{[]byte("abc"), "abc"},
+		{42, "42"},
+		{int8(-8), "-8"},
+		{int64(math.MinInt64), "-9223372036854775808"},
+		{uint64(math.MaxUint64), "18446744073709551615"},
+		{0.5, ".5"},
+		{-0.25, "-.25"},
+		{1.0, "1"},
+		{100.0, "100"},
+		{0.0, "0"},
+		{math.Copysign(0, -1), "0"},
+		{float32(1.5), "1.5"},
+		{1e21, "1000000000000000000000"},
+		{big.NewRat(-3, 4), "-.75"},
+		{big.NewInt(-7), "-7"},
+		{math.Inf(1), "+Inf"},
+		{true, "true"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, yottadb.CanonicalizeSubscript(test.value), "value %v", test.value)
+	}
+}
+
+func TestCanonicalizeSubscriptLimits(t *testing.T) {
+	// Numbers beyond the limits of numeric collation are formatted exactly but are not canonical numbers
+	for _, value := range []interface{}{uint64(math.MaxUint64), int64(1234567890123456789), 1e300, 1e-50, big.NewRat(10, 3)} {
+		sub := yottadb.CanonicalizeSubscript(value)
+		assert.False(t, yottadb.IsCanonicalNumber(sub), "%q should not be canonical", sub)
+	}
+	assert.Equal(t, "1"+strings.Repeat("0", 300), yottadb.CanonicalizeSubscript(1e300))
+	// A big.Rat with no exact decimal form is rounded to 18 decimal places
+	assert.Equal(t, ".333333333333333333", yottadb.CanonicalizeSubscript(big.NewRat(1, 3)))
+	assert.Equal(t, "3.333333333333333333", yottadb.CanonicalizeSubscript(big.NewRat(10, 3)))
+	assert.True(t, yottadb.IsCanonicalNumber(yottadb.CanonicalizeSubscript(big.NewRat(1, 3))))
+}
+
+func TestTypedSubscripts(t *testing.T) {
+	subs := []string{"42", "-8", ".5", "1000000000000000000000", "1.50", "012", "", "abc", "123456789012345678"}
+	expected := []interface{}{int64(42), int64(-8), 0.5, 1e21, "1.50", "012", "", "abc", int64(123456789012345678)}
+	assert.Equal(t, expected, yottadb.TypedSubscripts(subs))
+	// Typed subscripts canonicalize back to the subscripts they came from
+	for _, sub := range subs {
+		assert.Equal(t, sub, yottadb.CanonicalizeSubscript(yottadb.TypedSubscript(sub)))
+	}
+}
+
+func TestCanonicalizeSubscriptCollation(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// Numeric subscripts collate before string subscripts, and canonicalized numbers must be seen as numbers
+	values := []interface{}{"1.50", 10, 2.5, "abc", -1, 0.5}
+	for _, value := range values {
+		err := yottadb.SetValE(yottadb.NOTTP, &errstr, "", "^collation", []string{yottadb.CanonicalizeSubscript(value)})
+		Assertnoerr(err, t)
+	}
+	expected := []string{"-1", ".5", "2.5", "10", "1.50", "abc"}
+	sub := ""
+	for _, exp := range expected {
+		next, err := yottadb.SubNextE(yottadb.NOTTP, &errstr, "^collation", []string{sub})
+		Assertnoerr(err, t)
+		assert.Equal(t, exp, next)
+		sub = next
+	}
+}
+
+func TestCompareSubscripts(t *testing.T) {
+	assert.Equal(t, 0, yottadb.CompareSubscripts("", ""))
+	assert.Equal(t, -1, yottadb.CompareSubscripts("", "-5"))
+	assert.Equal(t, -1, yottadb.CompareSubscripts("-5", ".5"))
+	assert.Equal(t, -1, yottadb.CompareSubscripts("9", "10"))
+	assert.Equal(t, 1, yottadb.CompareSubscripts("10", "9"))
+	assert.Equal(t, -1, yottadb.CompareSubscripts("10", "09"))
+	assert.Equal(t, 1, yottadb.CompareSubscripts("abc", "ABC"))
+	assert.Equal(t, 0, yottadb.CompareSubscripts("1.5", "1.5"))
+	subs := []string{"b", "10", "1.50", "", "-1", "2", "a", ".5"}
+	yottadb.SortSubscripts(subs)
+	assert.Equal(t, []string{"", "-1", ".5", "2", "10", "1.50", "a", "b"}, subs)
+}
+
+func TestCollationComparator(t *testing.T) {
+	reverse := func(a, b string) int { return strings.Compare(b, a) }
+	assert.Nil(t, yottadb.CollationComparator(1))
+	yottadb.RegisterCollation(1, reverse)
+	defer yottadb.RegisterCollation(1, nil)
+	cmp := yottadb.CollationComparator(1)
+	assert.NotNil(t, cmp)
+	// Strings use the registered comparison while null and numeric subscripts are unchanged
+	assert.Equal(t, 1, cmp("a", "b"))
+	assert.Equal(t, -1, cmp("2", "10"))
+	assert.Equal(t, -1, cmp("10", "a"))
+	assert.Equal(t, -1, cmp("", "1"))
+	assert.Equal(t, -1, yottadb.CollationComparator(0)("a", "b"))
+	assert.Panics(t, func() { yottadb.RegisterCollation(0, reverse) })
+	assert.Panics(t, func() { yottadb.RegisterCollation(256, reverse) })
+}
@@ -0,0 +1,115 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package config loads YAML and TOML documents into YottaDB trees and saves trees as YAML and TOML documents, so that
+configuration held in global variables can be managed as files, for example kept in version control and reviewed like
+code. YAML is parsed and written with gopkg.in/yaml.v3 and TOML with github.com/BurntSushi/toml.
+
+Each mapping (YAML) or table (TOML) of a document becomes a node whose children are subscripted by the keys of the
+mapping, each sequence or array a node whose children are subscripted by the zero-based indexes of its items (as JSON
+arrays are by yottadb.JSONPathSubscripts()), and each scalar the value of a node. YAML scalars are stored as written, so
+0.50 is stored as "0.50" rather than as a number, and null as the empty string. TOML values are decoded by type, so
+strings are stored as their contents and numbers in canonical form (see LoadTOML()). For example, the YAML document:
+
+	smtp:
+	  host: mail.example.com
+	  port: 25
+	admins: [alice, bob]
+
+loaded with LoadYAML() into ^cfg sets the nodes ^cfg("admins",0)="alice", ^cfg("admins",1)="bob",
+^cfg("smtp","host")="mail.example.com" and ^cfg("smtp","port")=25, as does the TOML document:
+
+	admins = ["alice", "bob"]
+
+	[smtp]
+	host = "mail.example.com"
+	port = 25
+
+loaded with LoadTOML(). SaveYAML() and SaveTOML() write the tree back as an equivalent document.
+*/
+package config
+
+import (
+	"errors"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+)
+
+// ErrValueWithChildren is returned by SaveYAML() and SaveTOML() for a node that has both a value and descendants, which
+// neither YAML nor TOML can represent
+var ErrValueWithChildren = errors.New("config: a node with both a value and descendants cannot be saved")
+
+// configNode is a node of a tree read by readTree() to be saved as a document
+type configNode struct {
+	value    string        // Value of the node, if it has no children
+	subs     []string      // Subscripts of the children of the node in collation order
+	children []*configNode // Children of the node, in the order of subs
+	sequence bool          // Whether the children are subscripted 0, 1, 2 and so on without gaps
+}
+
+// replaceTree is a function to replace the tree rooted at varname(subary...) with nodes in one transaction
+func replaceTree(tptoken uint64, errstr *yottadb.BufferT, nodes []yottadb.TreeNode, varname string, subary []string) error {
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		if err := yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, varname, subary); nil != err {
+			return struct{}{}, err
+		}
+		for _, node := range nodes {
+			if err := yottadb.SetValE(tptoken, errstr, node.Value, varname, node.Subary); nil != err {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	}, "", []string{})
+	return err
+}
+
+// readTree is a function to return the tree rooted at varname(subary...), or nil if it has no nodes. If a node has both a
+// value and descendants, ErrValueWithChildren is returned.
+func readTree(tptoken uint64, errstr *yottadb.BufferT, varname string, subary []string) (*configNode, error) {
+	data, err := yottadb.DataE(tptoken, errstr, varname, subary)
+	if nil != err {
+		return nil, err
+	}
+	switch data {
+	case 0:
+		return nil, nil
+	case 1:
+		value, err := yottadb.ValE(tptoken, errstr, varname, subary)
+		if nil != err {
+			return nil, err
+		}
+		return &configNode{value: value}, nil
+	case 11:
+		return nil, ErrValueWithChildren
+	}
+	node := &configNode{sequence: true}
+	child := append(subary[:len(subary):len(subary)], "")
+	for {
+		next, err := yottadb.SubNextE(tptoken, errstr, varname, child)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND != yottadb.ErrorCode(err) {
+				return nil, err
+			}
+			break
+		}
+		child[len(child)-1] = next
+		value, err := readTree(tptoken, errstr, varname, child)
+		if nil != err {
+			return nil, err
+		}
+		node.sequence = node.sequence && strconv.Itoa(len(node.subs)) == next
+		node.subs = append(node.subs, next)
+		node.children = append(node.children, value)
+	}
+	return node, nil
+}
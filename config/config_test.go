@@ -0,0 +1,133 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package config_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"lang.yottadb.com/go/yottadb/config"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestLoadAndSaveYAML(t *testing.T) {
+	var out bytes.Buffer
+
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^config", []string{})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "stale", "^config", []string{"app", "old"}), t)
+	doc := "smtp:\n  host: mail.example.com\n  port: 25\n  ratio: 0.50\nadmins: [alice, bob]\nenabled: true\nnote: ~\n"
+	Assertnoerr(config.LoadYAML(yottadb.NOTTP, nil, strings.NewReader(doc), "^config", []string{"app"}), t)
+	expected := map[string][]string{
+		"mail.example.com": {"app", "smtp", "host"},
+		"25":               {"app", "smtp", "port"},
+		"0.50":             {"app", "smtp", "ratio"},
+		"alice":            {"app", "admins", "0"},
+		"bob":              {"app", "admins", "1"},
+		"true":             {"app", "enabled"},
+		"":                 {"app", "note"},
+	}
+	for value, subary := range expected {
+		val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", subary)
+		Assertnoerr(err, t)
+		assert.Equal(t, value, val)
+	}
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, "^config", []string{"app", "old"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+
+	Assertnoerr(config.SaveYAML(yottadb.NOTTP, nil, &out, "^config", []string{"app"}), t)
+	assert.Equal(t, "admins:\n  - alice\n  - bob\nenabled: \"true\"\nnote: \"\"\nsmtp:\n  host: mail.example.com\n  port: 25\n"+
+		"  ratio: \"0.50\"\n", out.String())
+	// The saved document loads back into the same tree
+	Assertnoerr(config.LoadYAML(yottadb.NOTTP, nil, &out, "^config", []string{"copy"}), t)
+	for value, subary := range expected {
+		val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", append([]string{"copy"}, subary[1:]...))
+		Assertnoerr(err, t)
+		assert.Equal(t, value, val)
+	}
+
+	// A document that cannot be parsed leaves the tree unchanged, and a node with a value and descendants cannot be saved
+	assert.NotNil(t, config.LoadYAML(yottadb.NOTTP, nil, strings.NewReader("a: [b"), "^config", []string{"app"}))
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", []string{"app", "smtp", "port"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "25", val)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "x", "^config", []string{"app", "smtp"}), t)
+	assert.Equal(t, config.ErrValueWithChildren, config.SaveYAML(yottadb.NOTTP, nil, &out, "^config", []string{"app"}))
+}
+
+func TestLoadAndSaveTOML(t *testing.T) {
+	var out bytes.Buffer
+
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^config", []string{})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "stale", "^config", []string{"app", "old"}), t)
+	doc := "# Mail settings\nadmins = [\"alice\", 'bob']\nenabled = true\nratio = 0.50\n\n[smtp]\nhost = \"mail.example.com\"\n" +
+		"port = 25 # SMTP\n\n[[servers]]\nname = \"a\\tb\"\n\n[[servers]]\nname = \"\"\"\nc\"\"\"\n"
+	Assertnoerr(config.LoadTOML(yottadb.NOTTP, nil, strings.NewReader(doc), "^config", []string{"app"}), t)
+	expected := map[string][]string{
+		"mail.example.com": {"app", "smtp", "host"},
+		"25":               {"app", "smtp", "port"},
+		".5":               {"app", "ratio"},
+		"alice":            {"app", "admins", "0"},
+		"bob":              {"app", "admins", "1"},
+		"true":             {"app", "enabled"},
+		"a\tb":             {"app", "servers", "0", "name"},
+		"c":                {"app", "servers", "1", "name"},
+	}
+	for value, subary := range expected {
+		val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", subary)
+		Assertnoerr(err, t)
+		assert.Equal(t, value, val)
+	}
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, "^config", []string{"app", "old"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+
+	Assertnoerr(config.SaveTOML(yottadb.NOTTP, nil, &out, "^config", []string{"app"}), t)
+	assert.Equal(t, "admins = [\"alice\", \"bob\"]\nenabled = \"true\"\nratio = 0.5\n\n[[servers]]\nname = \"a\\tb\"\n\n"+
+		"[[servers]]\nname = \"c\"\n\n[smtp]\nhost = \"mail.example.com\"\nport = 25\n", out.String())
+	// The saved document loads back into the same tree
+	Assertnoerr(config.LoadTOML(yottadb.NOTTP, nil, &out, "^config", []string{"copy"}), t)
+	for value, subary := range expected {
+		val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", append([]string{"copy"}, subary[1:]...))
+		Assertnoerr(err, t)
+		assert.Equal(t, value, val)
+	}
+
+	// A document that cannot be parsed, such as one defining a key twice, leaves the tree unchanged
+	for _, bad := range []string{"a = [1", "a = 1\na = 2\n", "[smtp]\n[smtp]\n", "a = yes\n", "a = 1 b = 2\n"} {
+		assert.NotNil(t, config.LoadTOML(yottadb.NOTTP, nil, strings.NewReader(bad), "^config", []string{"app"}), bad)
+	}
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^config", []string{"app", "smtp", "port"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "25", val)
+	// Trees TOML cannot represent are not saved
+	out.Reset()
+	assert.Equal(t, config.ErrNotTable, config.SaveTOML(yottadb.NOTTP, nil, &out, "^config", []string{"app", "ratio"}))
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "\xff", "^config", []string{"app", "binary"}), t)
+	assert.Equal(t, config.ErrNotUTF8, config.SaveTOML(yottadb.NOTTP, nil, &out, "^config", []string{"app"}))
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "x", "^config", []string{"app", "smtp"}), t)
+	assert.Equal(t, config.ErrValueWithChildren, config.SaveTOML(yottadb.NOTTP, nil, &out, "^config", []string{"app"}))
+	assert.Equal(t, 0, out.Len())
+	// An empty document deletes the tree
+	Assertnoerr(config.LoadTOML(yottadb.NOTTP, nil, strings.NewReader("# Nothing\n"), "^config", []string{"app"}), t)
+	data, err = yottadb.DataE(yottadb.NOTTP, nil, "^config", []string{"app"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
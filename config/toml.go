@@ -0,0 +1,205 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"github.com/BurntSushi/toml"
+	"io"
+	"lang.yottadb.com/go/yottadb"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// ErrNotTable is returned by SaveTOML() for a tree whose root has a value, as a TOML document is a table
+var ErrNotTable = errors.New("config: a tree whose root has a value cannot be saved as TOML")
+
+// ErrNotUTF8 is returned by SaveTOML() for a subscript or value that is not valid UTF-8, which TOML cannot represent
+var ErrNotUTF8 = errors.New("config: a subscript or value that is not valid UTF-8 cannot be saved as TOML")
+
+// LoadTOML is a function to replace the tree rooted at varname(subary...) with the nodes of the TOML document read from
+// reader, as LoadYAML() does for a YAML document. An empty document deletes the tree. LoadTOML() may be called within a
+// transaction, in which case tptoken must be the token of that transaction.
+//
+// Strings are stored as their contents, integers in decimal, floats as canonical numbers (so 0.50 is stored as ".5") or as
+// inf, -inf and nan, booleans as true and false, and dates and times in RFC 3339 format. Arrays of tables are stored as
+// arrays are, the tables being subscripted by their indexes.
+func LoadTOML(tptoken uint64, errstr *yottadb.BufferT, reader io.Reader, varname string, subary []string) error {
+	var doc map[string]interface{}
+
+	if _, err := toml.NewDecoder(reader).Decode(&doc); nil != err {
+		return err
+	}
+	nodes := appendTOMLNodes(nil, doc, append([]string{}, subary...))
+	return replaceTree(tptoken, errstr, nodes, varname, subary)
+}
+
+// SaveTOML is a function to write the tree rooted at varname(subary...) to writer as a TOML document that LoadTOML() loads
+// back into the same tree. A node whose child subscripts are 0, 1, 2 and so on without gaps is written as an array if its
+// children all have values, or as an array of tables if they all have descendants, and any other node with descendants
+// as a table. Canonical numbers that load back unchanged are written as numbers and other values as strings.
+//
+// A tree with no nodes is written as an empty document. Nothing is written if the tree cannot be represented: if the root
+// has a value ErrNotTable is returned, if a node has both a value and descendants ErrValueWithChildren is returned, and if
+// a subscript or value is not valid UTF-8 ErrNotUTF8 is returned. As with SaveYAML(), the document is not a consistent
+// snapshot if other processes update the tree meanwhile, unless SaveTOML() is called within a transaction.
+func SaveTOML(tptoken uint64, errstr *yottadb.BufferT, writer io.Writer, varname string, subary []string) error {
+	var doc bytes.Buffer
+
+	root, err := readTree(tptoken, errstr, varname, append([]string{}, subary...))
+	if nil != err {
+		return err
+	}
+	if nil == root {
+		return nil
+	}
+	if nil == root.children {
+		return ErrNotTable
+	}
+	table, err := tomlTable(root)
+	if nil != err {
+		return err
+	}
+	encoder := toml.NewEncoder(&doc)
+	encoder.Indent = ""
+	if err = encoder.Encode(table); nil != err {
+		return err
+	}
+	_, err = writer.Write(doc.Bytes())
+	return err
+}
+
+// appendTOMLNodes is a function to append the nodes of a decoded TOML value, stored at the node with subscripts subary,
+// to nodes
+func appendTOMLNodes(nodes []yottadb.TreeNode, value interface{}, subary []string) []yottadb.TreeNode {
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for key, entry := range val {
+			nodes = appendTOMLNodes(nodes, entry, append(subary[:len(subary):len(subary)], key))
+		}
+	case []map[string]interface{}:
+		for i, item := range val {
+			nodes = appendTOMLNodes(nodes, item, append(subary[:len(subary):len(subary)], strconv.Itoa(i)))
+		}
+	case []interface{}:
+		for i, item := range val {
+			nodes = appendTOMLNodes(nodes, item, append(subary[:len(subary):len(subary)], strconv.Itoa(i)))
+		}
+	default:
+		nodes = append(nodes, yottadb.TreeNode{Subary: subary, Value: tomlScalar(val)})
+	}
+	return nodes
+}
+
+// tomlScalar is a function to return the value stored for a decoded TOML scalar
+func tomlScalar(value interface{}) string {
+	switch val := value.(type) {
+	case float64:
+		switch {
+		case math.IsNaN(val):
+			return "nan"
+		case math.IsInf(val, 1):
+			return "inf"
+		case math.IsInf(val, -1):
+			return "-inf"
+		}
+	case time.Time:
+		// The decoder gives dates and times without a time zone locations with these names
+		switch val.Location().String() {
+		case "datetime-local":
+			return val.Format("2006-01-02T15:04:05.999999999")
+		case "date-local":
+			return val.Format("2006-01-02")
+		case "time-local":
+			return val.Format("15:04:05.999999999")
+		}
+		return val.Format(time.RFC3339Nano)
+	}
+	return yottadb.CanonicalizeSubscript(value)
+}
+
+// tomlTable is a function to return a node with descendants as the value the TOML encoder writes as a table
+func tomlTable(node *configNode) (map[string]interface{}, error) {
+	table := make(map[string]interface{}, len(node.children))
+	for i, child := range node.children {
+		if !utf8.ValidString(node.subs[i]) {
+			return nil, ErrNotUTF8
+		}
+		value, err := tomlValue(child)
+		if nil != err {
+			return nil, err
+		}
+		table[node.subs[i]] = value
+	}
+	return table, nil
+}
+
+// tomlValue is a function to return a node as the value the TOML encoder writes for it: a number or string for a node with
+// a value, an array or array of tables for a sequence whose items all have values or all have descendants, and a table
+// otherwise
+func tomlValue(node *configNode) (interface{}, error) {
+	if nil == node.children {
+		return tomlLeaf(node.value)
+	}
+	leaves, tables := 0, 0
+	for _, child := range node.children {
+		if nil == child.children {
+			leaves++
+		} else {
+			tables++
+		}
+	}
+	switch {
+	case node.sequence && 0 == tables:
+		array := make([]interface{}, len(node.children))
+		for i, child := range node.children {
+			var err error
+
+			if array[i], err = tomlLeaf(child.value); nil != err {
+				return nil, err
+			}
+		}
+		return array, nil
+	case node.sequence && 0 == leaves:
+		array := make([]map[string]interface{}, len(node.children))
+		for i, child := range node.children {
+			var err error
+
+			if array[i], err = tomlTable(child); nil != err {
+				return nil, err
+			}
+		}
+		return array, nil
+	}
+	return tomlTable(node)
+}
+
+// tomlLeaf is a function to return a value as an int64 or float64 if it is a canonical number that LoadTOML() loads back
+// unchanged, and as a string otherwise
+func tomlLeaf(value string) (interface{}, error) {
+	if !utf8.ValidString(value) {
+		return nil, ErrNotUTF8
+	}
+	if yottadb.IsCanonicalNumber(value) {
+		if num, err := strconv.ParseInt(value, 10, 64); nil == err {
+			return num, nil
+		}
+		if num, err := strconv.ParseFloat(value, 64); nil == err && value == yottadb.CanonicalizeSubscript(num) {
+			return num, nil
+		}
+	}
+	return value, nil
+}
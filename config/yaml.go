@@ -0,0 +1,133 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"io"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+)
+
+// LoadYAML is a function to replace the tree rooted at varname(subary...) with the nodes of the YAML document read from
+// reader. The tree is deleted and the nodes set in one transaction, so other processes see either the old configuration
+// or the new one, and a document that cannot be parsed leaves the tree unchanged. An empty or null document deletes the
+// tree. LoadYAML() may be called within a transaction, in which case tptoken must be the token of that transaction.
+//
+// Mapping keys must be scalars. Anchors and aliases are expanded, so an alias is stored as a copy of what it refers to.
+func LoadYAML(tptoken uint64, errstr *yottadb.BufferT, reader io.Reader, varname string, subary []string) error {
+	var doc yaml.Node
+	var nodes []yottadb.TreeNode
+
+	if err := yaml.NewDecoder(reader).Decode(&doc); nil != err && io.EOF != err {
+		return err
+	}
+	if 0 < len(doc.Content) && "!!null" != doc.Content[0].Tag {
+		var err error
+
+		if nodes, err = appendNodes(nodes, doc.Content[0], append([]string{}, subary...)); nil != err {
+			return err
+		}
+	}
+	return replaceTree(tptoken, errstr, nodes, varname, subary)
+}
+
+// SaveYAML is a function to write the tree rooted at varname(subary...) to writer as a YAML document that LoadYAML() loads
+// back into the same tree. A node whose child subscripts are 0, 1, 2 and so on without gaps is written as a sequence and
+// any other node with descendants as a mapping. If a node has both a value and descendants, ErrValueWithChildren is
+// returned and nothing is written. A tree with no nodes is written as null.
+//
+// The tree is read into memory before it is written, as configuration trees are small. As with yottadb.DumpTreeE(), the
+// document is not a consistent snapshot if other processes update the tree meanwhile, unless SaveYAML() is called within
+// a transaction.
+func SaveYAML(tptoken uint64, errstr *yottadb.BufferT, writer io.Writer, varname string, subary []string) error {
+	root, err := readTree(tptoken, errstr, varname, append([]string{}, subary...))
+	if nil != err {
+		return err
+	}
+	encoder := yaml.NewEncoder(writer)
+	encoder.SetIndent(2)
+	if err = encoder.Encode(yamlNode(root)); nil != err {
+		return err
+	}
+	return encoder.Close()
+}
+
+// appendNodes is a function to append the nodes of the YAML node doc, stored at the node with subscripts subary, to nodes
+func appendNodes(nodes []yottadb.TreeNode, doc *yaml.Node, subary []string) ([]yottadb.TreeNode, error) {
+	switch doc.Kind {
+	case yaml.ScalarNode:
+		value := doc.Value
+		if "!!null" == doc.Tag {
+			value = ""
+		}
+		return append(nodes, yottadb.TreeNode{Subary: subary, Value: value}), nil
+	case yaml.AliasNode:
+		return appendNodes(nodes, doc.Alias, subary)
+	case yaml.SequenceNode:
+		var err error
+
+		for i, item := range doc.Content {
+			if nodes, err = appendNodes(nodes, item, append(subary[:len(subary):len(subary)], strconv.Itoa(i))); nil != err {
+				return nil, err
+			}
+		}
+		return nodes, nil
+	case yaml.MappingNode:
+		var err error
+
+		for i := 0; i+1 < len(doc.Content); i += 2 {
+			key := doc.Content[i]
+			if yaml.ScalarNode != key.Kind {
+				return nil, fmt.Errorf("config: line %d: mapping keys must be scalars", key.Line)
+			}
+			if nodes, err = appendNodes(nodes, doc.Content[i+1], append(subary[:len(subary):len(subary)], key.Value)); nil != err {
+				return nil, err
+			}
+		}
+		return nodes, nil
+	default:
+		return nil, fmt.Errorf("config: line %d: unsupported YAML node", doc.Line)
+	}
+}
+
+// yamlNode is a function to return a tree read by readTree() as a YAML node
+func yamlNode(node *configNode) *yaml.Node {
+	switch {
+	case nil == node:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+	case nil == node.children:
+		return scalarNode(node.value)
+	case node.sequence:
+		retval := &yaml.Node{Kind: yaml.SequenceNode}
+		for _, child := range node.children {
+			retval.Content = append(retval.Content, yamlNode(child))
+		}
+		return retval
+	}
+	retval := &yaml.Node{Kind: yaml.MappingNode}
+	for i, child := range node.children {
+		retval.Content = append(retval.Content, scalarNode(node.subs[i]), yamlNode(child))
+	}
+	return retval
+}
+
+// scalarNode is a function to return a YAML scalar node for a value or mapping key. Canonical numbers are written as
+// plain scalars and other strings are quoted if they would otherwise be read as another type, such as true or null.
+func scalarNode(value string) *yaml.Node {
+	if yottadb.IsCanonicalNumber(value) {
+		return &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
@@ -0,0 +1,227 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ydbMaxNumericDigits is the number of significant decimal digits YottaDB maintains for a numeric value. Rational values
+// that have no exact decimal representation (e.g. 1/3) are rounded to this many decimal places before being handed to the
+// engine, which would otherwise round them itself.
+const ydbMaxNumericDigits int = 18
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Exact decimal flavors of the Easy API value fetch and increment functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ValDecimalE is an Easy API function to return the value found for varname(subary...) as an exact decimal number.
+//
+// YottaDB does its arithmetic in decimal so a value such as 0.1 is stored exactly, but converting it to a float64 (as is
+// commonly done with strconv.ParseFloat() on the result of ValE()) introduces binary rounding error. ValDecimalE() instead
+// wraps ValE() and converts the value to a big.Rat without going through float64. The value is interpreted the same way
+// M interprets a string in a numeric context, so a value with no leading numeric portion (e.g. "abc") is zero and one
+// with trailing non-numeric characters (e.g. "12abc") is the number in its leading portion.
+//
+// If ValE() returns an error such as GVUNDEF or LVUNDEF, the function returns the error.
+func ValDecimalE(tptoken uint64, errstr *BufferT, varname string, subary []string) (*big.Rat, error) {
+	printEntry("ValDecimalE()")
+	value, err := ValE(tptoken, errstr, varname, subary)
+	if nil != err {
+		return nil, err
+	}
+	return decimalFromM(value), nil
+}
+
+// IncrDecimalE is an Easy API function to increment the given node by an exact decimal amount and return the new value.
+//
+// Matching IncrE(), IncrDecimalE() wraps ydb_incr_st() to atomically increment the referenced global or local variable
+// node coerced to a number, except that the increment is supplied and the result returned as a big.Rat so no float64
+// conversions (and their rounding errors) are involved. With a nil value for incr, the increment is 1. An increment that
+// has no exact decimal representation (e.g. 1/3) is rounded to 18 decimal places, the maximum precision of a YottaDB number.
+//
+// If ydb_incr_st() returns an error such as NUMOFLOW, the function returns the error. Otherwise, it returns the incremented
+// value of the node.
+func IncrDecimalE(tptoken uint64, errstr *BufferT, incr *big.Rat, varname string, subary []string) (*big.Rat, error) {
+	var incrstr string
+
+	printEntry("IncrDecimalE()")
+	if nil == incr {
+		incrstr = "1"
+	} else {
+		incrstr = decimalToM(incr)
+	}
+	value, err := IncrE(tptoken, errstr, incrstr, varname, subary)
+	if nil != err {
+		return nil, err
+	}
+	return decimalFromM(value), nil
+}
+
+// IncrClampE is an Easy API function to increment the given node, keeping its value within bounds, and return the new value
+// and whether it was clamped to a bound. It is intended for counters such as quotas and rate limits.
+//
+// The value of the node, incr, lower and upper are interpreted as numbers as M does (see ValDecimalE()) with an undefined node
+// treated as 0, and the arithmetic is exact. With an empty incr, the increment is 1. If the incremented value is below lower
+// it is set to lower, and if it is above upper it is set to upper; an empty lower or upper is no bound. The node is read and set in
+// a transaction, so concurrent increments are applied one after the other. IncrClampE() may be called within a transaction,
+// in which case tptoken must be the token of that transaction.
+//
+// If a database call returns an error, the function returns the error and the node is unchanged.
+func IncrClampE(tptoken uint64, errstr *BufferT, incr, lower, upper, varname string, subary []string) (string, bool, error) {
+	type clampResult struct {
+		value   string
+		clamped bool
+	}
+
+	printEntry("IncrClampE()")
+	incrval := decimalFromM(selectString("" == incr, "1", incr))
+	if "" != lower && "" != upper && 0 < decimalFromM(lower).Cmp(decimalFromM(upper)) {
+		panic(fmt.Sprintf("YDB: lower (%s) of IncrClampE() is greater than upper (%s)", lower, upper))
+	}
+	result, err := TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (clampResult, error) {
+		var result clampResult
+
+		value, err := ValE(tptoken, errstr, varname, subary)
+		if nil != err && YDB_ERR_GVUNDEF != ErrorCode(err) && YDB_ERR_LVUNDEF != ErrorCode(err) {
+			return result, err
+		}
+		newval := new(big.Rat).Add(decimalFromM(value), incrval)
+		if "" != lower && 0 > newval.Cmp(decimalFromM(lower)) {
+			newval = decimalFromM(lower)
+			result.clamped = true
+		}
+		if "" != upper && 0 < newval.Cmp(decimalFromM(upper)) {
+			newval = decimalFromM(upper)
+			result.clamped = true
+		}
+		result.value = decimalToM(newval)
+		return result, SetValE(tptoken, errstr, result.value, varname, subary)
+	}, "", []string{})
+	if nil != err {
+		return "", false, err
+	}
+	return result.value, result.clamped, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// decimalToM is a function to format a big.Rat as a decimal string YottaDB can use as a number. Values whose denominator
+// only has factors of 2 and 5 have an exact (terminating) decimal representation and are formatted exactly. Others are
+// rounded to ydbMaxNumericDigits decimal places.
+func decimalToM(value *big.Rat) string {
+	if value.IsInt() {
+		return value.Num().String()
+	}
+	places, exact := decimalPlaces(value)
+	if !exact { // A non-terminating decimal - round it
+		places = ydbMaxNumericDigits
+	}
+	retval := strings.TrimRight(value.FloatString(places), "0")
+	return strings.TrimSuffix(retval, ".")
+}
+
+// numberInRange is a function to determine whether YottaDB can represent a number: it must be less than 1E47 and, if not
+// zero, at least 1E-43 in magnitude
+func numberInRange(value *big.Rat) bool {
+	if 0 == value.Sign() {
+		return true
+	}
+	ten := big.NewInt(10)
+	maxval := new(big.Rat).SetInt(new(big.Int).Exp(ten, big.NewInt(int64(ydbMaxNumericExponent)), nil))
+	minval := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Exp(ten, big.NewInt(int64(ydbMinNumericExponent)), nil))
+	absval := new(big.Rat).Abs(value)
+	return 0 > absval.Cmp(maxval) && 0 <= absval.Cmp(minval)
+}
+
+// decimalPlaces is a function to return the number of decimal places needed to represent a big.Rat exactly, and whether
+// it has an exact (terminating) decimal representation at all, which it does only if its denominator has no factors other
+// than 2 and 5.
+func decimalPlaces(value *big.Rat) (int, bool) {
+	var quo, rem big.Int
+	var twos, fives int
+
+	// Count the factors of 2 and 5 in the denominator - the larger count is the number of decimal places needed
+	denom := new(big.Int).Set(value.Denom())
+	for 0 == denom.Bit(0) {
+		denom.Rsh(denom, 1)
+		twos++
+	}
+	five := big.NewInt(5)
+	for {
+		quo.QuoRem(denom, five, &rem)
+		if 0 != rem.Sign() {
+			break
+		}
+		denom.Set(&quo)
+		fives++
+	}
+	return max(twos, fives), 1 == denom.BitLen() // Any factor left other than 2 or 5 means a non-terminating decimal
+}
+
+// decimalFromM is a function to convert a string to a big.Rat following the M rules for interpreting a string in a numeric
+// context. Any number of leading signs are allowed (an odd number of minus signs makes the value negative), followed by
+// digits with an optional decimal point and an optional exponent. Evaluation stops at the first character that does not
+// fit this pattern and a string with no numeric portion evaluates to zero.
+func decimalFromM(value string) *big.Rat {
+	var numstr strings.Builder
+	var i int
+
+	negative := false
+	for ; i < len(value) && ('+' == value[i] || '-' == value[i]); i++ {
+		if '-' == value[i] {
+			negative = !negative
+		}
+	}
+	if negative {
+		numstr.WriteByte('-')
+	}
+	mantissaStart := i
+	for ; i < len(value) && '0' <= value[i] && '9' >= value[i]; i++ {
+	}
+	if i < len(value) && '.' == value[i] {
+		for i++; i < len(value) && '0' <= value[i] && '9' >= value[i]; i++ {
+		}
+	}
+	mantissa := strings.TrimSuffix(value[mantissaStart:i], ".")
+	if "" == mantissa {
+		return new(big.Rat) // No numeric portion - the value is zero
+	}
+	numstr.WriteString(mantissa)
+	// An exponent is only recognized if at least one digit follows the E (and its optional sign)
+	if i < len(value) && 'E' == value[i] {
+		j := i + 1
+		if j < len(value) && ('+' == value[j] || '-' == value[j]) {
+			j++
+		}
+		expStart := j
+		for ; j < len(value) && '0' <= value[j] && '9' >= value[j]; j++ {
+		}
+		if j > expStart {
+			numstr.WriteString(value[i:j])
+		}
+	}
+	retval, ok := new(big.Rat).SetString(numstr.String())
+	if !ok {
+		return new(big.Rat)
+	}
+	return retval
+}
@@ -0,0 +1,122 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"math/big"
+	"testing"
+)
+
+func TestValDecimalE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// Pairs of stored value and the exact decimal ValDecimalE() should see for it
+	values := [][2]string{
+		{"0.1", "1/10"},
+		{"-.5", "-1/2"},
+		{"12abc", "12"},
+		{"--1.5E2xyz", "150"},
+		{"1.E", "1"},
+		{"abc", "0"},
+		{"", "0"},
+	}
+	for _, value := range values {
+		err := yottadb.SetValE(yottadb.NOTTP, &errstr, value[0], "^decimal", []string{"val"})
+		Assertnoerr(err, t)
+		rat, err := yottadb.ValDecimalE(yottadb.NOTTP, &errstr, "^decimal", []string{"val"})
+		Assertnoerr(err, t)
+		expected, ok := new(big.Rat).SetString(value[1])
+		assert.True(t, ok)
+		assert.Equal(t, 0, expected.Cmp(rat), "value %q fetched as %s", value[0], rat)
+	}
+	// Errors from the underlying fetch are passed back
+	_, err := yottadb.ValDecimalE(yottadb.NOTTP, &errstr, "^decimal", []string{"undefined"})
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+}
+
+func TestIncrDecimalE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// 0.1 + 0.2 is exactly 0.3 in decimal (but not in float64)
+	err := yottadb.SetValE(yottadb.NOTTP, &errstr, "0.1", "^decimal", []string{"incr"})
+	Assertnoerr(err, t)
+	rat, err := yottadb.IncrDecimalE(yottadb.NOTTP, &errstr, big.NewRat(2, 10), "^decimal", []string{"incr"})
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, big.NewRat(3, 10).Cmp(rat))
+	val, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^decimal", []string{"incr"})
+	Assertnoerr(err, t)
+	assert.Equal(t, ".3", val)
+	// A nil increment adds 1
+	rat, err = yottadb.IncrDecimalE(yottadb.NOTTP, &errstr, nil, "^decimal", []string{"incr"})
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, big.NewRat(13, 10).Cmp(rat))
+	// Negative and large increments keep full precision
+	incr, _ := new(big.Rat).SetString("-123456789012.345678")
+	rat, err = yottadb.IncrDecimalE(yottadb.NOTTP, &errstr, incr, "^decimal", []string{"incr"})
+	Assertnoerr(err, t)
+	expected, _ := new(big.Rat).SetString("-123456789011.045678")
+	assert.Equal(t, 0, expected.Cmp(rat))
+	// An increment with no exact decimal representation is rounded to 18 places
+	rat, err = yottadb.IncrDecimalE(yottadb.NOTTP, &errstr, big.NewRat(1, 3), "^decimal", []string{"third"})
+	Assertnoerr(err, t)
+	expected, _ = new(big.Rat).SetString(".333333333333333333")
+	assert.Equal(t, 0, expected.Cmp(rat))
+	// Errors from the underlying increment are passed back
+	_, err = yottadb.IncrDecimalE(yottadb.NOTTP, &errstr, big.NewRat(1, 1), "^", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+}
+
+func TestIncrClampE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// An undefined node starts at 0
+	val, clamped, err := yottadb.IncrClampE(yottadb.NOTTP, &errstr, "5", "", "10", "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "5", val)
+	assert.False(t, clamped)
+	val, clamped, err = yottadb.IncrClampE(yottadb.NOTTP, &errstr, "5", "", "10", "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "10", val)
+	assert.False(t, clamped)
+	// Increments beyond the upper bound are clamped
+	val, clamped, err = yottadb.IncrClampE(yottadb.NOTTP, &errstr, "5", "", "10", "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "10", val)
+	assert.True(t, clamped)
+	// Decrements below the lower bound are clamped
+	val, clamped, err = yottadb.IncrClampE(yottadb.NOTTP, &errstr, "-12.5", "0", "", "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "0", val)
+	assert.True(t, clamped)
+	// An empty increment adds 1
+	val, clamped, err = yottadb.IncrClampE(yottadb.NOTTP, &errstr, "", "0", "10", "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "1", val)
+	assert.False(t, clamped)
+	val, err = yottadb.ValE(yottadb.NOTTP, &errstr, "^clamp", []string{"quota"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "1", val)
+	// Errors are passed back
+	_, _, err = yottadb.IncrClampE(yottadb.NOTTP, &errstr, "1", "", "", "^", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+	assert.Panics(t, func() { yottadb.IncrClampE(yottadb.NOTTP, &errstr, "1", "10", "0", "^clamp", []string{"quota"}) })
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2023 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -35,20 +35,59 @@ The Easy API provides a set of functions that are very easy to use at the expens
 some additional copies for each operation. These functions all end with the letter 'E',
 and are available in the yottadb package. They include:
 
+    - ChSetE
+    - ChunkedTpE
     - DataE
     - DeleteE
     - DeleteExclE
+    - DeleteGlobalsE
+    - DumpTreeE
+    - EncodeJSONE
+    - EnsureE
+    - GblDirE
+    - GlobalNamesE
+    - IncrClampE
+    - IncrDecimalE
     - IncrE
+    - IsDeletedE
+    - JSONPathValE
     - LockDecrE
     - LockIncrE
     - LockE
+    - LookupE
+    - MaxTPTimeE
+    - ModTimeE
     - NodeNextE
     - NodePrevE
+    - OnceE
+    - RestoreLocalsE
+    - SaveLocalsE
+    - SetGblDirE
+    - SetJSONPathE
+    - SetMaxTPTimeE
+    - SetTreeE
     - SetValE
+    - SetValTrackedE
+    - SoftDeleteE
+    - Str2ZwrE
     - SubNextE
     - SubPrevE
+    - SubRangeE
+    - SubRangeSoftE
+    - SubSliceE
+    - SwapE
+    - TLevelE
     - TpE
+    - TpValE
+    - UndeleteE
+    - ValDecimalE
     - ValE
+    - ValLenE
+    - ValidateChSetE
+    - Zwr2StrE
+
+The Pipeline structure records a sequence of Easy API operations and executes them together
+in a single transaction with its ExecE method.
 
 Please see the Easy API example below for usage.
 
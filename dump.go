@@ -0,0 +1,194 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"unicode/utf8"
+)
+
+var valueRedactor func(node, value string) string // Function set by SetRedactor(), nil if none
+var valueRedactorMutex sync.Mutex                 // Mutex for access to valueRedactor
+
+// Output formats of DumpTreeE()
+const (
+	DumpZwrite int = iota // ZWRITE format, e.g. ^x("a",1)="value", as output by the ZWRITE command
+	DumpJSON              // JSON Lines - one object per node, e.g. {"name":"^x","subscripts":["a","1"],"value":"value"}
+	DumpTable             // Aligned columns of the variable name, each subscript and the value, separated by spaces
+)
+
+// DumpOptions is a structure containing options for DumpTreeE(). The zero value dumps every node in ZWRITE format.
+type DumpOptions struct {
+	Format   int // Output format (DumpZwrite, DumpJSON or DumpTable)
+	MaxLines int // Maximum number of nodes to output, 0 for no limit
+	// MaxValue is the maximum length in bytes of values to output, 0 for no limit. Longer values are truncated, before
+	// the character that would cross the limit if they are UTF-8, and end in "...".
+	MaxValue int
+	// Redact, if not nil, is called with the variable name, subscripts and value of each node and returns the value to
+	// output in its place, for example to hide sensitive values in logs. If nil, any function set by SetRedactor() is used.
+	Redact func(varname string, subary []string, value string) string
+}
+
+// DumpTreeE is an Easy API function to write the nodes that have a value in the tree rooted at varname(subary...) to
+// writer, in collation order, in the format and subject to the limits given in opts (which may be nil for defaults).
+// If MaxLines stops the output before the end of the tree, a final line "..." is written in the ZWRITE and table formats
+// so the truncation is visible. The JSON format writes nothing more, so every line remains a valid JSON object.
+//
+// If opts.Format is not one of the formats above, the INVDUMPFMT error is returned before anything is written. The nodes
+// are fetched one at a time, so the dump is not a consistent snapshot if other processes update the tree
+// meanwhile, unless DumpTreeE() is called within a transaction.
+func DumpTreeE(tptoken uint64, errstr *BufferT, writer io.Writer, varname string, subary []string, opts *DumpOptions) error {
+	var lines int
+	var line []byte // Buffer for lines in ZWRITE format, reused for each node
+	var table *tabwriter.Writer
+
+	printEntry("DumpTreeE()")
+	if nil == opts {
+		opts = &DumpOptions{}
+	}
+	if DumpZwrite != opts.Format && DumpJSON != opts.Format && DumpTable != opts.Format {
+		errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVDUMPFMT), "!AD", strconv.Itoa(opts.Format), 1)
+		return &YDBError{errcode: (int)(YDB_ERR_INVDUMPFMT), errmsg: errmsg}
+	}
+	if DumpTable == opts.Format {
+		table = tabwriter.NewWriter(writer, 0, 8, 1, ' ', 0)
+		writer = table
+	}
+	dumpNode := func(subs []string) error {
+		value, err := ValE(tptoken, errstr, varname, subs)
+		if nil != err {
+			return err
+		}
+		if nil != opts.Redact {
+			value = opts.Redact(varname, subs, value)
+		} else {
+			value = redactValue(ZwriteName(varname, subs), value)
+		}
+		if 0 < opts.MaxValue && opts.MaxValue < len(value) {
+			value = truncateValue(value, opts.MaxValue) + "..."
+		}
+		switch opts.Format {
+		case DumpZwrite:
+			line = appendZwriteName(line[:0], varname, subs)
+			line = append(QuoteAppend(append(line, '='), value), '\n')
+			_, err = writer.Write(line)
+		case DumpJSON:
+			var jsonLine []byte
+
+			jsonLine, err = json.Marshal(struct {
+				Name       string   `json:"name"`
+				Subscripts []string `json:"subscripts"`
+				Value      string   `json:"value"`
+			}{varname, subs, value})
+			if nil == err {
+				_, err = fmt.Fprintf(writer, "%s\n", jsonLine)
+			}
+		case DumpTable:
+			_, err = fmt.Fprintf(writer, "%s\t%s\n", strings.Join(append([]string{varname}, subs...), "\t"), value)
+		}
+		return err
+	}
+	// The root of the tree is only dumped if it has a value, then NodeNextE() finds each node below it in turn
+	data, err := DataE(tptoken, errstr, varname, subary)
+	if nil != err {
+		return err
+	}
+	subs := subary
+	if 1 == data%10 {
+		err = dumpNode(subs)
+		lines++
+	}
+	for 1 < data && nil == err {
+		subs, err = NodeNextE(tptoken, errstr, varname, subs)
+		if nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				err = nil
+			}
+			break
+		}
+		if len(subs) <= len(subary) || !equalSubscripts(subary, subs[:len(subary)]) {
+			break // Past the end of the tree
+		}
+		if 0 < opts.MaxLines && opts.MaxLines <= lines {
+			if DumpJSON != opts.Format {
+				_, err = fmt.Fprintln(writer, "...")
+			}
+			break
+		}
+		err = dumpNode(subs)
+		lines++
+	}
+	if nil != table {
+		if flushErr := table.Flush(); nil == err {
+			err = flushErr
+		}
+	}
+	return err
+}
+
+// SetRedactor is a function to set a function that the debugging helpers of the wrapper call for each value they output,
+// to output the value it returns in its place. This keeps sensitive values (such as personal data) out of logs produced
+// by those helpers, which are DumpTreeE() (unless DumpOptions.Redact is set) and the Dump() and DumpToWriter() methods
+// of BufferT and BufferTArray. The function is passed the name of the node in ZWRITE format (e.g. ^x("a",1)), or an empty
+// string where the value is not known to belong to a node, such as the contents of a BufferT. Passing nil removes the
+// redactor. The function may be called from multiple goroutines at once.
+func SetRedactor(redactor func(node, value string) string) {
+	printEntry("SetRedactor()")
+	valueRedactorMutex.Lock()
+	valueRedactor = redactor
+	valueRedactorMutex.Unlock()
+}
+
+// redactValue is a function to return the value to output in place of the value of a node, using the function set by
+// SetRedactor().
+func redactValue(node, value string) string {
+	valueRedactorMutex.Lock()
+	redactor := valueRedactor
+	valueRedactorMutex.Unlock()
+	if nil == redactor {
+		return value
+	}
+	return redactor(node, value)
+}
+
+// truncateValue is a function to return the first maxLen bytes of value, backing up to the start of the character that
+// crosses maxLen, if there is one, so a UTF-8 value is not cut within a character
+func truncateValue(value string, maxLen int) string {
+	cut := maxLen
+	for cut > maxLen-(utf8.UTFMax-1) && 0 < cut && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	if utf8.RuneStart(value[cut]) {
+		return value[:cut]
+	}
+	return value[:maxLen] // Not UTF-8
+}
+
+// equalSubscripts is a function to report whether two lists of subscripts are the same.
+func equalSubscripts(subary1, subary2 []string) bool {
+	if len(subary1) != len(subary2) {
+		return false
+	}
+	for i := range subary1 {
+		if subary1[i] != subary2[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,103 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestDumpTreeE(t *testing.T) {
+	var errstr yottadb.BufferT
+	var out bytes.Buffer
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	for _, node := range [][]string{{"a", "1"}, {"a", "2", "x"}, {"b"}} {
+		err := yottadb.SetValE(yottadb.NOTTP, &errstr, "v"+node[len(node)-1], "^dumptree", append([]string{"t"}, node...))
+		Assertnoerr(err, t)
+	}
+	err := yottadb.SetValE(yottadb.NOTTP, &errstr, "secret\tvalue", "^dumptree", []string{"t"})
+	Assertnoerr(err, t)
+	err = yottadb.SetValE(yottadb.NOTTP, &errstr, "outside", "^dumptree", []string{"u"})
+	Assertnoerr(err, t)
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t"}, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, "^dumptree(\"t\")=\"secret\"_$C(9)_\"value\"\n^dumptree(\"t\",\"a\",1)=\"v1\"\n"+
+		"^dumptree(\"t\",\"a\",2,\"x\")=\"vx\"\n^dumptree(\"t\",\"b\")=\"vb\"\n", out.String())
+	// Limits and redaction
+	out.Reset()
+	opts := yottadb.DumpOptions{MaxLines: 2, MaxValue: 3, Redact: func(varname string, subary []string, value string) string {
+		if 1 == len(subary) {
+			return "<redacted>"
+		}
+		return value
+	}}
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t"}, &opts)
+	Assertnoerr(err, t)
+	assert.Equal(t, "^dumptree(\"t\")=\"<re...\"\n^dumptree(\"t\",\"a\",1)=\"v1\"\n...\n", out.String())
+	// Machine readable formats
+	out.Reset()
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t", "a"}, &yottadb.DumpOptions{Format: yottadb.DumpJSON})
+	Assertnoerr(err, t)
+	assert.Equal(t, `{"name":"^dumptree","subscripts":["t","a","1"],"value":"v1"}`+"\n"+
+		`{"name":"^dumptree","subscripts":["t","a","2","x"],"value":"vx"}`+"\n", out.String())
+	out.Reset()
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t", "a"}, &yottadb.DumpOptions{Format: yottadb.DumpTable})
+	Assertnoerr(err, t)
+	assert.Equal(t, "^dumptree t a 1 v1\n^dumptree t a 2 x vx\n", out.String())
+	// Values are not truncated within a UTF-8 character
+	err = yottadb.SetValE(yottadb.NOTTP, &errstr, "h\u00e9llo", "^dumptree", []string{"t", "b"})
+	Assertnoerr(err, t)
+	out.Reset()
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t", "b"}, &yottadb.DumpOptions{MaxValue: 2})
+	Assertnoerr(err, t)
+	assert.Equal(t, "^dumptree(\"t\",\"b\")=\"h...\"\n", out.String())
+	// An unknown format is an error, and nothing is written
+	out.Reset()
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^dumptree", []string{"t"}, &yottadb.DumpOptions{Format: 99})
+	assert.Equal(t, yottadb.YDB_ERR_INVDUMPFMT, yottadb.ErrorCode(err))
+	assert.Equal(t, "", out.String())
+}
+
+func TestSetRedactor(t *testing.T) {
+	var errstr yottadb.BufferT
+	var buft yottadb.BufferT
+	var out bytes.Buffer
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	err := yottadb.SetValE(yottadb.NOTTP, &errstr, "123-45-6789", "^redact", []string{"ssn"})
+	Assertnoerr(err, t)
+	yottadb.SetRedactor(func(node, value string) string {
+		if "" == node || "^redact(\"ssn\")" == node {
+			return "***"
+		}
+		return value
+	})
+	defer yottadb.SetRedactor(nil)
+	err = yottadb.DumpTreeE(yottadb.NOTTP, &errstr, &out, "^redact", []string{}, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, "^redact(\"ssn\")=\"***\"\n", out.String())
+	buft.Alloc(32)
+	defer buft.Free()
+	err = buft.SetValStr(yottadb.NOTTP, &errstr, "123-45-6789")
+	Assertnoerr(err, t)
+	out.Reset()
+	buft.DumpToWriter(&out)
+	assert.Contains(t, out.String(), "value: ***")
+	assert.NotContains(t, out.String(), "6789")
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -15,6 +15,7 @@ package yottadb
 import (
 	"fmt"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync/atomic"
 )
@@ -42,6 +43,9 @@ func DataE(tptoken uint64, errstr *BufferT, varname string, subary []string) (ui
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return 0, err
+	}
 	defer dbkey.Free()
 	initkey(tptoken, errstr, &dbkey, varname, subary)
 	if nil != errstr {
@@ -49,9 +53,16 @@ func DataE(tptoken uint64, errstr *BufferT, varname string, subary []string) (ui
 	}
 	vargobuft := dbkey.Varnm.getCPtr()
 	subbuftary := dbkey.Subary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := beginNodeOp(tptoken, opData, varname, subary, nil)
+		if nil != err {
+			return 0, err
+		}
+		defer end()
+	}
 	rc := C.ydb_data_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(dbkey.Subary.ElemUsed()), subbuftary, &retval)
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "DataE")
 		return uint32(retval), err
 	}
 	runtime.KeepAlive(dbkey) // Make sure dbkey stays in tact through the call into YDB
@@ -74,6 +85,9 @@ func DeleteE(tptoken uint64, errstr *BufferT, deltype int, varname string, subar
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	defer dbkey.Free()
 	initkey(tptoken, errstr, &dbkey, varname, subary)
 	if nil != errstr {
@@ -81,10 +95,17 @@ func DeleteE(tptoken uint64, errstr *BufferT, deltype int, varname string, subar
 	}
 	vargobuft := dbkey.Varnm.getCPtr()
 	subbuftary := dbkey.Subary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := beginNodeOp(tptoken, opDelete, varname, subary, nil)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_delete_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(dbkey.Subary.ElemUsed()), subbuftary,
 		C.int(deltype))
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "DeleteE")
 		return err
 	}
 	runtime.KeepAlive(dbkey) // Make sure dbkey stays in tact through the call into YDB
@@ -132,7 +153,50 @@ func DeleteExclE(tptoken uint64, errstr *BufferT, varnames []string) error {
 		panic(fmt.Sprintf("YDB: Unexpected error with SetUsed(): %s", err))
 	}
 	// Drive simpleAPI wrapper and return its return code
-	return vnames.DeleteExclST(tptoken, errstr)
+	return withOperation(vnames.DeleteExclST(tptoken, errstr), "DeleteExclE")
+}
+
+// EnsureE is an Easy API function to set each child of varname(subary...) named by a key of defaults that has no value to
+// the default value of that key, and return the subscripts of the children it set in collation order. Children that
+// already have a value are left unchanged, so EnsureE() can initialize a tree of settings without overwriting settings
+// that were changed. Default values that are numbers are stored as canonical numbers M reads back as the same numbers:
+// integers exactly, floating point values with the fewest digits that convert back to the same value (e.g. 0.5 as ".5")
+// and big.Rat values exactly or, if they have no exact decimal form (e.g. 1/3), rounded to 18 decimal places. If the default
+// value of a child that has no value is a number YottaDB cannot represent (NaN, an infinity, a magnitude of 1E47 or more
+// or a non-zero magnitude less than 1E-43), EnsureE() returns a NUMOFLOW error and sets no children.
+//
+// EnsureE() checks and sets the children in one transaction, so either all the missing children are set or none are, and
+// no other process can set a child between the check and the set. It may be called within a transaction, in which case
+// tptoken must be the token of that transaction.
+func EnsureE(tptoken uint64, errstr *BufferT, defaults map[string]interface{}, varname string, subary []string) ([]string,
+	error) {
+	var childsubs []string
+
+	printEntry("EnsureE()")
+	childsubs = append(append(childsubs, subary...), "")
+	return TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) ([]string, error) {
+		created := []string{}
+		for sub, value := range defaults {
+			childsubs[len(childsubs)-1] = sub
+			data, err := DataE(tptoken, errstr, varname, childsubs)
+			if nil != err {
+				return nil, err
+			}
+			if 0 != data%10 {
+				continue
+			}
+			str, err := formatValue(tptoken, errstr, value)
+			if nil != err {
+				return nil, err
+			}
+			if err = SetValE(tptoken, errstr, str, varname, childsubs); nil != err {
+				return nil, err
+			}
+			created = append(created, sub)
+		}
+		SortSubscripts(created)
+		return created, nil
+	}, "", []string{})
 }
 
 // ValE is an STAPI function to return the value found for varname(subary...)
@@ -154,14 +218,15 @@ func ValE(tptoken uint64, errstr *BufferT, varname string, subary []string) (str
 	initkey(tptoken, errstr, &dbkey, varname, subary)
 	dataSize = easyAPIDefaultDataSize
 	dbvalue.Alloc(dataSize)
-	// Attempt to fetch the value multiple times. We do not know how big the incoming record is so loop till it fits.
-	for {
+	// Attempt to fetch the value multiple times. We do not know how big the incoming record is so loop till it fits, or
+	// until the value has grown between fetches easyAPIMaxReallocs times, as it could if another process keeps growing it.
+	for reallocs := 0; ; reallocs++ {
 		// dbvalue is allocated with current best-guess size of returning data
 		err = dbkey.ValST(tptoken, errstr, &dbvalue)
 		if nil != err {
 			// Check if we had an INVSTRLEN error (too small an output buffer)
 			errorcode := ErrorCode(err)
-			if int(YDB_ERR_INVSTRLEN) == errorcode {
+			if int(YDB_ERR_INVSTRLEN) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INVSTRLEN - reallocate the size we need
 				dataSize = uint32(dbvalue.getCPtr().len_used)
 				dbvalue.Free()
@@ -169,7 +234,7 @@ func ValE(tptoken uint64, errstr *BufferT, varname string, subary []string) (str
 				continue
 			}
 			// Otherwise an unexpected error occurred. Return that.
-			return "", err
+			return "", withOperation(err, "ValE")
 		}
 		break // No error so success and we are done!
 	}
@@ -180,6 +245,62 @@ func ValE(tptoken uint64, errstr *BufferT, varname string, subary []string) (str
 	return retval, nil
 }
 
+// ValLenE is an Easy API function to return the length in bytes of the value of varname(subary...) without fetching the
+// value, as KeyT.ValLenST() does. Errors such as GVUNDEF are returned with a length of 0.
+func ValLenE(tptoken uint64, errstr *BufferT, varname string, subary []string) (uint32, error) {
+	var dbkey KeyT
+
+	printEntry("ValLenE()")
+	defer dbkey.Free()
+	initkey(tptoken, errstr, &dbkey, varname, subary)
+	length, err := dbkey.ValLenST(tptoken, errstr)
+	return length, withOperation(err, "ValLenE")
+}
+
+// States of a node returned by LookupE()
+const (
+	LookupUndefined int = iota // The node has neither a value nor descendants
+	LookupTreeOnly             // The node has descendants but no value
+	LookupHasValue             // The node has a value (and possibly descendants)
+)
+
+// LookupE is an Easy API function to return the value of varname(subary...) and whether it exists, in one call for the
+// common case of a node with a value. Rather than calling DataE() to check the node has a value and then ValE() to fetch it,
+// LookupE() fetches the value and only if there is none calls ydb_data_st() to distinguish a node with descendants
+// (LookupTreeOnly) from one that does not exist (LookupUndefined). The value returned is empty unless the state is
+// LookupHasValue.
+//
+// A missing node is not an error. Other errors from ValE() or DataE(), such as INVVARNAME, are returned along with
+// LookupUndefined. If another process sets the node between the two calls, LookupE() fetches it again, up to three
+// times; if the node keeps being set and deleted meanwhile, the GVUNDEF or LVUNDEF error of the last fetch is returned.
+func LookupE(tptoken uint64, errstr *BufferT, varname string, subary []string) (string, int, error) {
+	var undef error
+
+	printEntry("LookupE()")
+	for tries := 0; 3 > tries; tries++ {
+		value, err := ValE(tptoken, errstr, varname, subary)
+		if nil == err {
+			return value, LookupHasValue, nil
+		}
+		if errcode := ErrorCode(err); YDB_ERR_GVUNDEF != errcode && YDB_ERR_LVUNDEF != errcode {
+			return "", LookupUndefined, err
+		}
+		undef = err
+		data, err := DataE(tptoken, errstr, varname, subary)
+		if nil != err {
+			return "", LookupUndefined, err
+		}
+		switch data {
+		case 0:
+			return "", LookupUndefined, nil
+		case 10:
+			return "", LookupTreeOnly, nil
+		}
+		// The node was set since ValE() was called, so fetch it again
+	}
+	return "", LookupUndefined, undef
+}
+
 // IncrE is a STAPI function to increment the given value by the given amount and return the new value.
 //
 // Matching IncrST(), IncrE() wraps ydb_incr_st() to atomically increment the referenced global or local variable node
@@ -198,6 +319,14 @@ func IncrE(tptoken uint64, errstr *BufferT, incr, varname string, subary []strin
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return "", err
+	}
+	if hookEnabled(hookValidate) && hasValidator(varname) {
+		// The validators need the incremented value before it is stored, so increment the node as IncrClampE() does
+		retval, _, err := IncrClampE(tptoken, errstr, incr, "", "", varname, subary)
+		return retval, err
+	}
 	defer dbkey.Free()
 	defer dbvalue.Free()
 	defer incrval.Free()
@@ -217,10 +346,17 @@ func IncrE(tptoken uint64, errstr *BufferT, incr, varname string, subary []strin
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := beginNodeOp(tptoken, opIncr, varname, subary, &dbvalue)
+		if nil != err {
+			return "", err
+		}
+		defer end()
+	}
 	rc := C.ydb_incr_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(dbkey.Subary.ElemUsed()), subbuftary,
 		incrval.getCPtr(), dbvalue.getCPtr())
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "IncrE")
 		return "", err
 	}
 	retval, err := dbvalue.ValStr(tptoken, errstr)
@@ -258,7 +394,7 @@ func LockE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, namesnsubs ...in
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching INVLKNMPAIRLIST: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_INVLKNMPAIRLIST), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_INVLKNMPAIRLIST), errmsg: errmsg}
 	}
 	lckparms := len(namesnsubs)
 	parmlst := make([]*KeyT, lckparms/2) // Allocate parameter list of *KeyT values
@@ -272,7 +408,7 @@ func LockE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, namesnsubs ...in
 			}
 			errmsg = strings.Replace(errmsg, "!AD", "%v", -1)
 			errmsg = fmt.Sprintf(errmsg, newVarname, "LockE()")
-			return &YDBError{(int)(YDB_ERR_PARAMINVALID), errmsg}
+			return &YDBError{errcode: (int)(YDB_ERR_PARAMINVALID), errmsg: errmsg}
 		}
 		// Pull in the next subscript array and verify it is an array of strings
 		newSubs, ok := namesnsubs[i+1].([]string)
@@ -283,7 +419,7 @@ func LockE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, namesnsubs ...in
 			}
 			errmsg = strings.Replace(errmsg, "!AD", "%v", -1)
 			errmsg = fmt.Sprintf(errmsg, newVarname, "LockE()")
-			return &YDBError{(int)(YDB_ERR_PARAMINVALID), errmsg}
+			return &YDBError{errcode: (int)(YDB_ERR_PARAMINVALID), errmsg: errmsg}
 		}
 		newKey := new(KeyT)
 		// Run through subscripts to find the biggest
@@ -312,7 +448,7 @@ func LockE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, namesnsubs ...in
 		}
 		parmlst[i/2] = newKey
 	}
-	return LockST(tptoken, errstr, timeoutNsec, parmlst...)
+	return withOperation(LockST(tptoken, errstr, timeoutNsec, parmlst...), "LockE")
 }
 
 // LockDecrE is a STAPI function to decrement the lock count of the given lock. When the count goes to 0, the lock
@@ -329,6 +465,9 @@ func LockDecrE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	defer dbkey.Free()
 	initkey(tptoken, errstr, &dbkey, varname, subary)
 	if nil != errstr {
@@ -338,7 +477,7 @@ func LockDecrE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	subbuftary := dbkey.Subary.getCPtr()
 	rc := C.ydb_lock_decr_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(dbkey.Subary.ElemUsed()), subbuftary)
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "LockDecrE")
 		return err
 	}
 	runtime.KeepAlive(dbkey) // Make sure dbkey stays in tact through the call into YDB
@@ -365,6 +504,9 @@ func LockIncrE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, varname stri
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	defer dbkey.Free()
 	initkey(tptoken, errstr, &dbkey, varname, subary)
 	if nil != errstr {
@@ -375,7 +517,7 @@ func LockIncrE(tptoken uint64, errstr *BufferT, timeoutNsec uint64, varname stri
 	rc := C.ydb_lock_incr_st(C.uint64_t(tptoken), cbuft, C.ulonglong(timeoutNsec), vargobuft,
 		C.int(dbkey.Subary.ElemUsed()), subbuftary)
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "LockIncrE")
 		return err
 	}
 	runtime.KeepAlive(dbkey) // Make sure dbkey stays in tact through the call into YDB
@@ -403,21 +545,21 @@ func NodeNextE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	subscrSize = easyAPIDefaultSubscrSize
 	dbsubs.Alloc(subscrCnt, subscrSize)
 	// Attempt to fetch the next subscript set multiple times. We do not know how big the incoming subscripts are
-	// so loop till they fit.
-	for {
+	// so loop till they fit, or until they have outgrown the buffers easyAPIMaxReallocs times.
+	for reallocs := 0; ; reallocs++ {
 		// dbvalue is allocated with current best-guess size of returning data
 		err = dbkey.NodeNextST(tptoken, errstr, &dbsubs)
 		if nil != err {
 			// Check if we had an INVSTRLEN error (too small an output buffer)
 			errorcode := ErrorCode(err)
-			if int(YDB_ERR_INSUFFSUBS) == errorcode {
+			if int(YDB_ERR_INSUFFSUBS) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INSUFFSUBS - pickup number of subscripts we actually need and reallocate
 				subscrCnt = dbsubs.ElemUsed()
 				dbsubs.Free()
 				dbsubs.Alloc(subscrCnt, subscrSize) // Reallocate and reset dbsubs
 				continue
 			}
-			if int(YDB_ERR_INVSTRLEN) == errorcode {
+			if int(YDB_ERR_INVSTRLEN) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INVSTRLEN - the last valid subscript (as shown by elemUsed) is the element
 				neededlen, err := dbsubs.ElemLenUsed(tptoken, errstr, dbsubs.ElemUsed())
 				if nil != err {
@@ -429,7 +571,7 @@ func NodeNextE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 				continue
 			}
 			// Otherwise some error happened so return that
-			return []string{}, err
+			return []string{}, withOperation(err, "NodeNextE")
 		}
 		break // No error so we had success and we are done!
 	}
@@ -468,21 +610,21 @@ func NodePrevE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	subscrSize = easyAPIDefaultSubscrSize
 	dbsubs.Alloc(subscrCnt, subscrSize)
 	// Attempt to fetch the next subscript set multiple times. We do not know how big the incoming subscripts are
-	// so loop till they fit.
-	for {
+	// so loop till they fit, or until they have outgrown the buffers easyAPIMaxReallocs times.
+	for reallocs := 0; ; reallocs++ {
 		// dbvalue is allocated with current best-guess size of returning data
 		err = dbkey.NodePrevST(tptoken, errstr, &dbsubs)
 		if nil != err {
 			// Check if we had an INVSTRLEN error (too small an output buffer)
 			errorcode := ErrorCode(err)
-			if int(YDB_ERR_INSUFFSUBS) == errorcode {
+			if int(YDB_ERR_INSUFFSUBS) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INSUFFSUBS - pickup number of subscripts we actually need and reallocate
 				subscrCnt = dbsubs.ElemUsed()
 				dbsubs.Free()
 				dbsubs.Alloc(subscrCnt, subscrSize)
 				continue
 			}
-			if int(YDB_ERR_INVSTRLEN) == errorcode {
+			if int(YDB_ERR_INVSTRLEN) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INVSTRLEN - the last valid subscript (as shown by elemUsed) is the element
 				neededlen, err := dbsubs.ElemLenUsed(tptoken, errstr, dbsubs.ElemUsed())
 				if nil != err {
@@ -494,7 +636,7 @@ func NodePrevE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 				continue
 			}
 			// Otherwise some error happened so return that
-			return []string{}, err
+			return []string{}, withOperation(err, "NodePrevE")
 		}
 		break // No error so success and we are done!
 	}
@@ -526,6 +668,9 @@ func SetValE(tptoken uint64, errstr *BufferT, value, varname string, subary []st
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	defer dbkey.Free()
 	defer dbvalue.Free()
 	subcnt := uint32(len(subary))
@@ -563,9 +708,16 @@ func SetValE(tptoken uint64, errstr *BufferT, value, varname string, subary []st
 	}
 	vargobuft := dbkey.Varnm.getCPtr()
 	subbuftary := dbkey.Subary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := beginNodeOp(tptoken, opSet, varname, subary, &dbvalue)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_set_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(dbkey.Subary.ElemUsed()), subbuftary, dbvalue.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "SetValE")
 		return err
 	}
 	runtime.KeepAlive(dbkey) // Make sure dbkey and dbvalue stays intact through the call into YDB
@@ -574,6 +726,36 @@ func SetValE(tptoken uint64, errstr *BufferT, value, varname string, subary []st
 	return nil
 }
 
+// SwapE is an Easy API function to set a value into the given node and return the value it replaced and whether the node
+// had one, as a single atomic operation.
+//
+// SwapE() reads and sets the node in a transaction, so no other process can set the node between the two. It may be called
+// within a transaction, in which case tptoken must be the token of that transaction. If the node had no value, SwapE() returns
+// an empty string and false.
+func SwapE(tptoken uint64, errstr *BufferT, value, varname string, subary []string) (string, bool, error) {
+	type swapResult struct {
+		value   string
+		existed bool
+	}
+
+	printEntry("SwapE()")
+	result, err := TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (swapResult, error) {
+		var result swapResult
+
+		old, err := ValE(tptoken, errstr, varname, subary)
+		if nil == err {
+			result = swapResult{old, true}
+		} else if errcode := ErrorCode(err); YDB_ERR_GVUNDEF != errcode && YDB_ERR_LVUNDEF != errcode {
+			return result, err
+		}
+		return result, SetValE(tptoken, errstr, value, varname, subary)
+	}, "", []string{})
+	if nil != err {
+		return "", false, err
+	}
+	return result.value, result.existed, nil
+}
+
 // SubNextE is a STAPI function to return the next subscript at the current subscript level.
 //
 // Matching SubNextST(), SubNextE() wraps ydb_subscript_next_st() to facilitate breadth-first traversal of a
@@ -597,14 +779,14 @@ func SubNextE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	subscrSize = easyAPIDefaultSubscrSize
 	dbsub.Alloc(subscrSize)
 	// Attempt to fetch the value multiple times. We do not know how big the incoming record is
-	// so loop till it fits.
-	for {
+	// so loop till it fits, or until it has outgrown the buffer easyAPIMaxReallocs times.
+	for reallocs := 0; ; reallocs++ {
 		// dbsub is allocated with current best-guess size of returning data
 		err = dbkey.SubNextST(tptoken, errstr, &dbsub)
 		if nil != err {
 			// Check if we had an INVSTRLEN error (too small an output buffer)
 			errorcode := ErrorCode(err)
-			if int(YDB_ERR_INVSTRLEN) == errorcode {
+			if int(YDB_ERR_INVSTRLEN) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INVSTRLEN - reallocate the size we need
 				subscrSize = uint32(dbsub.getCPtr().len_used)
 				dbsub.Free()
@@ -612,7 +794,7 @@ func SubNextE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 				continue
 			}
 			// Otherwise something badder-er happened
-			return "", err
+			return "", withOperation(err, "SubNextE")
 		}
 		break // No error so success and we are done!
 	}
@@ -646,14 +828,14 @@ func SubPrevE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 	subscrSize = easyAPIDefaultSubscrSize
 	dbsub.Alloc(subscrSize)
 	// Attempt to fetch the value multiple times. We do not know how big the incoming record is
-	// so loop till it fits.
-	for {
+	// so loop till it fits, or until it has outgrown the buffer easyAPIMaxReallocs times.
+	for reallocs := 0; ; reallocs++ {
 		// dbsub is allocated with current best-guess size of returning data
 		err = dbkey.SubPrevST(tptoken, errstr, &dbsub)
 		if nil != err {
 			// Check if we had an INVSTRLEN error (too small an output buffer)
 			errorcode := ErrorCode(err)
-			if int(YDB_ERR_INVSTRLEN) == errorcode {
+			if int(YDB_ERR_INVSTRLEN) == errorcode && easyAPIMaxReallocs > reallocs {
 				// This is INVSTRLEN - reallocate the size we need
 				subscrSize = uint32(dbsub.getCPtr().len_used)
 				dbsub.Free()
@@ -661,7 +843,7 @@ func SubPrevE(tptoken uint64, errstr *BufferT, varname string, subary []string)
 				continue
 			}
 			// Otherwise something badder-er happened
-			return "", err
+			return "", withOperation(err, "SubPrevE")
 		}
 		break // No error so success and we are done!
 	}
@@ -711,5 +893,139 @@ func TpE(tptoken uint64, errstr *BufferT, tpfn func(uint64, *BufferT) int32, tra
 		}
 	}
 	// Drive simpleAPI wrapper and return its return code
-	return vnames.TpST(tptoken, errstr, tpfn, transid)
+	return withOperation(vnames.TpST(tptoken, errstr, tpfn, transid), "TpE")
+}
+
+// TpValE is an Easy API function to drive a transaction whose closure returns a value as well as an error.
+//
+// Using TpE(), TpValE() runs tpfn as a transaction and returns the value tpfn returned when the transaction committed,
+// removing the need to pass results out of the transaction through variables captured by the closure. As with TpE(), tpfn
+// may be invoked multiple times if the transaction is restarted; only the value from the final (committed) invocation is
+// returned. The error returned by tpfn decides the outcome of the invocation:
+//
+//   - nil commits the transaction (unless it is nested, in which case the outermost transaction commits).
+//   - An error with the YDB_TP_RESTART code (as returned by calls that need the transaction restarted) restarts it.
+//   - Any other error rolls the transaction back and is returned by TpValE() along with the zero value of T. An error
+//     with the YDB_TP_ROLLBACK code may be returned to roll back explicitly.
+//
+// TpRestart() and TpRollback() may also be called to restart or roll back the transaction.
+//
+// If the transaction fails for another reason (e.g. TPTIMEOUT), that error is returned along with the zero value of T.
+// See TpE() for the meaning of the transid and varnames parameters.
+func TpValE[T any](tptoken uint64, errstr *BufferT, tpfn func(uint64, *BufferT) (T, error), transid string,
+	varnames []string) (T, error) {
+	var retval, zero T
+	var fnErr error
+
+	printEntry("TpValE()")
+	err := TpE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) int32 {
+		var val T
+
+		fnErr = nil // In case tpfn panics with TpRestart() or TpRollback()
+		val, fnErr = tpfn(tptoken, errstr)
+		if nil == fnErr {
+			retval = val
+			return YDB_OK
+		}
+		rc := ErrorCode(fnErr)
+		if YDB_TP_RESTART == rc {
+			fnErr = nil // Restarts are not the final outcome of the transaction
+			return YDB_TP_RESTART
+		}
+		if -1 == rc {
+			return YDB_TP_ROLLBACK // Not a YottaDB error - roll back and return it
+		}
+		return int32(rc)
+	}, transid, varnames)
+	if nil != fnErr {
+		return zero, fnErr
+	}
+	if nil != err {
+		return zero, err
+	}
+	return retval, nil
+}
+
+// TLevelE is an Easy API function to return the current transaction nesting level ($TLEVEL) - 0 outside a transaction,
+// 1 within a transaction and higher within nested transactions. Within a transaction, the tptoken passed to the closure
+// must be used. This allows code to verify it is (or is not) running within a transaction, for example:
+//
+//	if level, err := yottadb.TLevelE(tptoken, errstr); nil == err && 0 == level {
+//		panic("transfer() must be called within a transaction")
+//	}
+func TLevelE(tptoken uint64, errstr *BufferT) (int, error) {
+	printEntry("TLevelE()")
+	tlevel, err := ValE(tptoken, errstr, "$TLEVEL", []string{})
+	if nil != err {
+		return 0, err
+	}
+	level, err := strconv.Atoi(tlevel)
+	if nil != err {
+		panic(fmt.Sprintf("YDB: Unexpected value of $TLEVEL: %s", tlevel))
+	}
+	return level, nil
+}
+
+// Str2ZwrE is an Easy API function to return the given string in ZWRITE format, as converted by YottaDB itself.
+//
+// Matching Str2ZwrST(), Str2ZwrE() wraps ydb_str2zwr_st(), so its result is exactly what YottaDB produces for the string
+// in the current mode (M or UTF-8) - unlike Quote(), which does not call YottaDB.
+func Str2ZwrE(tptoken uint64, errstr *BufferT, str string) (string, error) {
+	printEntry("Str2ZwrE()")
+	return convertZwrE(tptoken, errstr, str, true)
+}
+
+// Zwr2StrE is an Easy API function to return the string represented by the given string in ZWRITE format, as converted by
+// YottaDB itself.
+//
+// Matching Zwr2StrST(), Zwr2StrE() wraps ydb_zwr2str_st(). If zwr is not in valid ZWRITE format, the function returns
+// the error from ydb_zwr2str_st(), or INVZWRSTR if YottaDB returns an empty string for a non-empty zwr without an error.
+func Zwr2StrE(tptoken uint64, errstr *BufferT, zwr string) (string, error) {
+	printEntry("Zwr2StrE()")
+	retval, err := convertZwrE(tptoken, errstr, zwr, false)
+	if nil == err && "" == retval && "" != zwr && "\"\"" != zwr {
+		return "", newInvZwrStrError(zwr)
+	}
+	return retval, err
+}
+
+// convertZwrE is a function to convert the given string to ZWRITE format (tozwr true) or from ZWRITE format (tozwr false)
+// with YottaDB, growing the output buffer until the result fits.
+func convertZwrE(tptoken uint64, errstr *BufferT, in string, tozwr bool) (string, error) {
+	var inval, outval BufferT
+	var err error
+	var dataSize uint32
+
+	defer inval.Free()
+	defer outval.Free()
+	inval.Alloc(uint32(len(in)))
+	err = inval.SetValStr(tptoken, errstr, in)
+	if nil != err {
+		panic(fmt.Sprintf("YDB: Unexpected error with SetValStr(): %s", err))
+	}
+	dataSize = easyAPIDefaultDataSize
+	outval.Alloc(dataSize)
+	for {
+		if tozwr {
+			err = inval.Str2ZwrST(tptoken, errstr, &outval)
+		} else {
+			err = inval.Zwr2StrST(tptoken, errstr, &outval)
+		}
+		if nil != err {
+			if int(YDB_ERR_INVSTRLEN) == ErrorCode(err) {
+				// Reallocate the output buffer with the size needed
+				dataSize = uint32(outval.getCPtr().len_used)
+				outval.Free()
+				outval.Alloc(dataSize)
+				continue
+			}
+			return "", withOperation(err, selectString(tozwr, "Str2ZwrE", "Zwr2StrE"))
+		}
+		break
+	}
+	retval, err := outval.ValStr(tptoken, errstr)
+	if nil != err {
+		panic(fmt.Sprintf("YDB: Unexpected error with ValStr(): %s", err))
+	}
+	return retval, nil
 }
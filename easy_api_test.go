@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -13,9 +13,14 @@
 package yottadb_test
 
 import (
+	"errors"
+	"github.com/stretchr/testify/assert"
 	"lang.yottadb.com/go/yottadb"
 	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"math"
+	"math/big"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -1041,3 +1046,172 @@ func TestTpE(t *testing.T) {
 	}
 
 }
+
+func TestTpValE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	incr := func(tptoken uint64, errstr *yottadb.BufferT) (int, error) {
+		val, err := yottadb.IncrE(tptoken, errstr, "", "^tpvale", []string{"count"})
+		if nil != err {
+			return 0, err
+		}
+		return strconv.Atoi(val)
+	}
+	// The value returned by the closure of a committed transaction is returned
+	count, err := yottadb.TpValE(yottadb.NOTTP, &errstr, incr, "", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, 1, count)
+	count, err = yottadb.TpValE(yottadb.NOTTP, &errstr, incr, "BATCH", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, count)
+	// A non-YottaDB error rolls the transaction back and is returned
+	errRollback := errors.New("roll back")
+	count, err = yottadb.TpValE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) (int, error) {
+		count, err := incr(tptoken, errstr)
+		Assertnoerr(err, t)
+		assert.Equal(t, 3, count)
+		return count, errRollback
+	}, "", []string{})
+	assert.Equal(t, errRollback, err)
+	assert.Equal(t, 0, count)
+	val, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^tpvale", []string{"count"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "2", val)
+	// A YottaDB error also rolls the transaction back and is returned
+	str, err := yottadb.TpValE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) (string, error) {
+		_, err := incr(tptoken, errstr)
+		Assertnoerr(err, t)
+		return yottadb.ValE(tptoken, errstr, "^tpvale", []string{"undefined"})
+	}, "", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	assert.Equal(t, "", str)
+	val, err = yottadb.ValE(yottadb.NOTTP, &errstr, "^tpvale", []string{"count"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "2", val)
+}
+
+func TestTLevelE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	level, err := yottadb.TLevelE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, level)
+	levels, err := yottadb.TpValE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) ([]int, error) {
+		outer, err := yottadb.TLevelE(tptoken, errstr)
+		if nil != err {
+			return nil, err
+		}
+		inner, err := yottadb.TpValE(tptoken, errstr, yottadb.TLevelE, "", []string{})
+		return []int{outer, inner}, err
+	}, "", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, []int{1, 2}, levels)
+}
+
+func TestStr2ZwrEAndZwr2StrE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// The long string needs a larger buffer than the default for both conversions
+	for _, str := range []string{"", "abc", "a\tb", "say \"hi\"", strings.Repeat("\x01x", 10000)} {
+		zwr, err := yottadb.Str2ZwrE(yottadb.NOTTP, &errstr, str)
+		Assertnoerr(err, t)
+		if 100 > len(str) {
+			assert.Equal(t, yottadb.Quote(str), zwr)
+		}
+		back, err := yottadb.Zwr2StrE(yottadb.NOTTP, &errstr, zwr)
+		Assertnoerr(err, t)
+		assert.Equal(t, str, back)
+	}
+	_, err := yottadb.Zwr2StrE(yottadb.NOTTP, &errstr, "\"unterminated")
+	assert.NotNil(t, err)
+}
+
+func TestLookupE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "value", "^lookup", []string{"a", "b"}), t)
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^lookup", []string{})
+	value, state, err := yottadb.LookupE(yottadb.NOTTP, &errstr, "^lookup", []string{"a", "b"})
+	Assertnoerr(err, t)
+	assert.Equal(t, yottadb.LookupHasValue, state)
+	assert.Equal(t, "value", value)
+	value, state, err = yottadb.LookupE(yottadb.NOTTP, &errstr, "^lookup", []string{"a"})
+	Assertnoerr(err, t)
+	assert.Equal(t, yottadb.LookupTreeOnly, state)
+	assert.Equal(t, "", value)
+	_, state, err = yottadb.LookupE(yottadb.NOTTP, &errstr, "^lookup", []string{"c"})
+	Assertnoerr(err, t)
+	assert.Equal(t, yottadb.LookupUndefined, state)
+	_, state, err = yottadb.LookupE(yottadb.NOTTP, &errstr, "lookuplocal", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, yottadb.LookupUndefined, state)
+	_, _, err = yottadb.LookupE(yottadb.NOTTP, &errstr, "^1bad", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+}
+
+func TestSwapE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^swap", []string{})
+	old, existed, err := yottadb.SwapE(yottadb.NOTTP, &errstr, "first", "^swap", []string{"state"})
+	Assertnoerr(err, t)
+	assert.False(t, existed)
+	assert.Equal(t, "", old)
+	old, existed, err = yottadb.SwapE(yottadb.NOTTP, &errstr, "second", "^swap", []string{"state"})
+	Assertnoerr(err, t)
+	assert.True(t, existed)
+	assert.Equal(t, "first", old)
+	value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^swap", []string{"state"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "second", value)
+	// Local variables can be swapped too, and an empty value counts as existing
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "", "swaplocal", []string{}), t)
+	old, existed, err = yottadb.SwapE(yottadb.NOTTP, &errstr, "x", "swaplocal", []string{})
+	Assertnoerr(err, t)
+	assert.True(t, existed)
+	assert.Equal(t, "", old)
+	_, _, err = yottadb.SwapE(yottadb.NOTTP, &errstr, "x", "^1bad", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+}
+
+func TestEnsureE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^ensure", []string{})
+	defaults := map[string]interface{}{"theme": "dark", "pagesize": 50, "ratio": 0.50, "10": true,
+		"maxid": uint64(18446744073709551615), "tenth": 0.1, "pi": 3.141592653589793, "third": big.NewRat(1, 3)}
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "light", "^ensure", []string{"user1", "theme"}), t)
+	created, err := yottadb.EnsureE(yottadb.NOTTP, &errstr, defaults, "^ensure", []string{"user1"})
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"10", "maxid", "pagesize", "pi", "ratio", "tenth", "third"}, created)
+	for sub, expected := range map[string]string{"theme": "light", "pagesize": "50", "ratio": ".5", "10": "true",
+		"maxid": "18446744073709551615", "tenth": ".1", "pi": "3.141592653589793", "third": ".333333333333333333"} {
+		value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^ensure", []string{"user1", sub})
+		Assertnoerr(err, t)
+		assert.Equal(t, expected, value)
+	}
+	created, err = yottadb.EnsureE(yottadb.NOTTP, &errstr, defaults, "^ensure", []string{"user1"})
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{}, created)
+	// Numbers YottaDB cannot represent are refused and set no children
+	for _, value := range []interface{}{1e300, math.Inf(1), math.NaN(), 1e-50} {
+		_, err = yottadb.EnsureE(yottadb.NOTTP, &errstr, map[string]interface{}{"a": 1, "huge": value}, "^ensure",
+			[]string{"user2"})
+		assert.Equal(t, yottadb.YDB_ERR_NUMOFLOW, yottadb.ErrorCode(err), "value %v", value)
+		data, err := yottadb.DataE(yottadb.NOTTP, &errstr, "^ensure", []string{"user2"})
+		Assertnoerr(err, t)
+		assert.Equal(t, uint32(0), data)
+	}
+}
@@ -0,0 +1,179 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package election elects one leader among the processes sharing a YottaDB database, for example to run a scheduled job in
+exactly one process of a fleet.
+
+A process campaigns for leadership of a named election by trying to acquire the YottaDB lock ^election(name) without
+waiting. The process holding the lock is the leader until it resigns or exits: YottaDB releases the locks of a process
+when it exits, even if it crashes, after which another process can win the election. While it leads, the process records
+its ID and a heartbeat time in the ^election(name,"leader") and ^election(name,"heartbeat") nodes, for observers to see
+which process leads and whether it is still working. A leader whose heartbeat is older than the TTL of the election is
+reported as no leader, since a process that is hung keeps its locks.
+
+For example:
+
+	elect := election.New("nightly-report", time.Minute)
+	for range time.Tick(10 * time.Second) {
+		leader, err := elect.Campaign(yottadb.NOTTP, nil)
+		...
+		if leader {
+			runReportIfDue()
+		}
+	}
+
+Locks are held by processes, not goroutines, so each process should use only one Election for a given name. As YottaDB
+locks are not replicated, all the processes in an election must use the same database instance.
+*/
+package election
+
+import (
+	"errors"
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() uses for the lock and the leader record
+const DefaultGlobal string = "^election"
+
+// ErrNotLeader is returned by Heartbeat() when the process does not lead the election
+var ErrNotLeader = errors.New("election: process is not the leader")
+
+// Election is a named election among the processes sharing a database
+type Election struct {
+	Global string        // Global variable name for the lock and the leader record, e.g. "^election"
+	Name   string        // Name of this election - the first subscript of the lock and of the leader record
+	TTL    time.Duration // Age after which the heartbeat of a leader is stale
+	ID     string        // ID of this process recorded while it leads, by default "hostname:pid"
+
+	mutex   sync.Mutex
+	leading bool
+}
+
+// New is a function to return the election with the given name and heartbeat TTL, using DefaultGlobal
+func New(name string, ttl time.Duration) *Election {
+	host, _ := os.Hostname()
+	return &Election{Global: DefaultGlobal, Name: name, TTL: ttl, ID: fmt.Sprintf("%s:%d", host, os.Getpid())}
+}
+
+// Campaign is a method to try once to become the leader of the election, without waiting, and return whether the process
+// leads. If it already leads, Campaign() records a new heartbeat. Calling Campaign() more often than the TTL both keeps
+// the heartbeat of a leader fresh and lets another process take over soon after a leader exits.
+func (elect *Election) Campaign(tptoken uint64, errstr *yottadb.BufferT) (bool, error) {
+	elect.mutex.Lock()
+	defer elect.mutex.Unlock()
+	if elect.leading {
+		return true, elect.heartbeat(tptoken, errstr)
+	}
+	err := yottadb.LockIncrE(tptoken, errstr, 0, elect.Global, []string{elect.Name})
+	if nil != err {
+		if yottadb.YDB_LOCK_TIMEOUT == yottadb.ErrorCode(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	_, err = yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		if err := yottadb.SetValE(tptoken, errstr, elect.ID, elect.Global, []string{elect.Name, "leader"}); nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, elect.heartbeat(tptoken, errstr)
+	}, "", []string{})
+	if nil != err {
+		yottadb.LockDecrE(tptoken, errstr, elect.Global, []string{elect.Name})
+		return false, err
+	}
+	elect.leading = true
+	return true, nil
+}
+
+// Heartbeat is a method to record that the leader is still working, and returns ErrNotLeader if the process does not lead
+// the election
+func (elect *Election) Heartbeat(tptoken uint64, errstr *yottadb.BufferT) error {
+	elect.mutex.Lock()
+	defer elect.mutex.Unlock()
+	if !elect.leading {
+		return ErrNotLeader
+	}
+	return elect.heartbeat(tptoken, errstr)
+}
+
+// IsLeader is a method to return whether the process leads the election, as of its last call to Campaign() or Resign()
+func (elect *Election) IsLeader() bool {
+	elect.mutex.Lock()
+	defer elect.mutex.Unlock()
+	return elect.leading
+}
+
+// Resign is a method to give up leadership of the election, deleting the leader record and releasing the lock so another
+// process can win. It does nothing if the process does not lead the election.
+func (elect *Election) Resign(tptoken uint64, errstr *yottadb.BufferT) error {
+	elect.mutex.Lock()
+	defer elect.mutex.Unlock()
+	if !elect.leading {
+		return nil
+	}
+	err := yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, elect.Global, []string{elect.Name})
+	if nil != err {
+		return err
+	}
+	elect.leading = false
+	return yottadb.LockDecrE(tptoken, errstr, elect.Global, []string{elect.Name})
+}
+
+// Leader is a method to return the ID and last heartbeat time of the leader of the election, for any process to observe.
+// It returns an empty ID if there is no leader, and an empty ID with the time of the last heartbeat if the heartbeat of the
+// leader is older than the TTL.
+func (elect *Election) Leader(tptoken uint64, errstr *yottadb.BufferT) (string, time.Time, error) {
+	type leaderRecord struct {
+		id        string
+		heartbeat time.Time
+	}
+
+	record, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (leaderRecord, error) {
+		var record leaderRecord
+
+		id, err := yottadb.ValE(tptoken, errstr, elect.Global, []string{elect.Name, "leader"})
+		if nil != err {
+			if yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err) {
+				return record, nil
+			}
+			return record, err
+		}
+		value, err := yottadb.ValE(tptoken, errstr, elect.Global, []string{elect.Name, "heartbeat"})
+		if nil != err {
+			return record, err
+		}
+		nsec, err := strconv.ParseInt(value, 10, 64)
+		if nil != err {
+			return record, fmt.Errorf("election: invalid heartbeat %q for %s", value, elect.Name)
+		}
+		return leaderRecord{id, time.Unix(0, nsec)}, nil
+	}, "", []string{})
+	if nil != err || "" == record.id {
+		return "", time.Time{}, err
+	}
+	if time.Since(record.heartbeat) > elect.TTL {
+		return "", record.heartbeat, nil
+	}
+	return record.id, record.heartbeat, nil
+}
+
+// heartbeat is a method to set the heartbeat node of the leader record to the current time
+func (elect *Election) heartbeat(tptoken uint64, errstr *yottadb.BufferT) error {
+	return yottadb.SetValE(tptoken, errstr, strconv.FormatInt(time.Now().UnixNano(), 10), elect.Global,
+		[]string{elect.Name, "heartbeat"})
+}
@@ -0,0 +1,66 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package election_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"lang.yottadb.com/go/yottadb/election"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestElection(t *testing.T) {
+	elect := election.New("job", time.Minute)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, elect.Global, []string{})
+	id, _, err := elect.Leader(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, "", id)
+	assert.Equal(t, election.ErrNotLeader, elect.Heartbeat(yottadb.NOTTP, nil))
+	before := time.Now()
+	leader, err := elect.Campaign(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.True(t, leader)
+	assert.True(t, elect.IsLeader())
+	id, heartbeat, err := elect.Leader(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, elect.ID, id)
+	assert.False(t, heartbeat.Before(before))
+	// Campaigning again while leading keeps the lead and records a new heartbeat
+	leader, err = elect.Campaign(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.True(t, leader)
+	Assertnoerr(elect.Heartbeat(yottadb.NOTTP, nil), t)
+	_, newHeartbeat, err := elect.Leader(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.False(t, newHeartbeat.Before(heartbeat))
+	// A leader whose heartbeat is older than the TTL is not reported
+	elect.TTL = time.Millisecond
+	time.Sleep(10 * time.Millisecond)
+	id, heartbeat, err = elect.Leader(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, "", id)
+	assert.False(t, heartbeat.IsZero())
+	Assertnoerr(elect.Resign(yottadb.NOTTP, nil), t)
+	assert.False(t, elect.IsLeader())
+	id, _, err = elect.Leader(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, "", id)
+	Assertnoerr(elect.Resign(yottadb.NOTTP, nil), t)
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -13,7 +13,10 @@
 package yottadb
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -49,16 +52,21 @@ const (
 // ydbGoErrEntry is a structure that contains the definition of a YDBGo wrapper-only error
 type ydbGoErrEntry struct {
 	errNum  C.uint32_t // Error number for this error
-	errName string     // Severity of the error (single char)
-	errSev  string     // Name of the error (e.g. MEMORY)
+	errName string     // Name of the error (e.g. MEMORY)
+	errSev  string     // Severity of the error (single char)
 	errText string     // Text of the error message (e.g. out of memory)
 }
 
 // YDBError is a structure that defines the error message format which includes both the formated $ZSTATUS
 // type message and the numeric error value.
 type YDBError struct {
-	errcode int    // The error value (e.g. YDB_ERR_DBFILERR, etc)
-	errmsg  string // The error string - generally from $ZSTATUS when available
+	errcode   int      // The error value (e.g. YDB_ERR_DBFILERR, etc)
+	errmsg    string   // The error string - generally from $ZSTATUS when available
+	cause     error    // The underlying error that led to this one (e.g. the cause passed to TpRollback()), if any
+	errstr    string   // The contents of errstr as returned by YottaDB, if any
+	zstatus   string   // The value of $ZSTATUS when the error was created, if recorded (see EnableErrorZStatus())
+	operation string   // The wrapper function or method that failed (e.g. "KeyT.ValST"), if known
+	params    []string // The variable name and subscripts the failing operation was passed, if known
 }
 
 // Error is a method to return the expected error message string.
@@ -66,12 +74,169 @@ func (err *YDBError) Error() string {
 	return err.errmsg
 }
 
-// ErrorCode is a function used to find the error return code.
+// Unwrap is a method to return the underlying error that led to this error, or nil if there is none. For example, the
+// ROLLBACK error returned by a transaction rolled back with TpRollback() wraps the cause passed to TpRollback(), so
+// errors.Is() and errors.As() can examine the cause.
+func (err *YDBError) Unwrap() error {
+	return err.cause
+}
+
+// Code is a method to return the error code (e.g. YDB_ERR_GVUNDEF) of the error.
+func (err *YDBError) Code() int {
+	return err.errcode
+}
+
+// Mnemonic is a method to return the mnemonic of the error (e.g. "GVUNDEF"), as it appears in the error message and in
+// the YottaDB documentation. An empty string is returned if the mnemonic cannot be determined.
+func (err *YDBError) Mnemonic() string {
+	switch err.errcode {
+	case YDB_TP_RESTART:
+		return "TPRESTART"
+	case YDB_TP_ROLLBACK:
+		return "ROLLBACK"
+	case YDB_LOCK_TIMEOUT:
+		return "LOCKTIMEOUT"
+	}
+	for i := range ydbGoErrors {
+		if -err.errcode == int(ydbGoErrors[i].errNum) {
+			return ydbGoErrors[i].errName
+		}
+	}
+	// Messages are of the form "%YDB-E-GVUNDEF, text", possibly preceded by the location of the error
+	msg := err.errmsg
+	idx := strings.Index(msg, "%YDB-")
+	if -1 == idx || len(msg) < idx+7 || '-' != msg[idx+6] {
+		return ""
+	}
+	msg = msg[idx+7:]
+	end := strings.IndexFunc(msg, func(r rune) bool { return ('A' > r || 'Z' < r) && ('0' > r || '9' < r) })
+	if -1 != end {
+		msg = msg[:end]
+	}
+	return msg
+}
+
+// Is is a method to report whether the error has the same error code as target, which allows errors.Is() to compare
+// errors with the sentinel errors such as ErrGVUNDEF, for example errors.Is(err, yottadb.ErrGVUNDEF).
+func (err *YDBError) Is(target error) bool {
+	yerr, ok := target.(*YDBError)
+	return ok && yerr.errcode == err.errcode
+}
+
+// Operation is a method to return the name of the wrapper function or method whose call to YottaDB failed with the error
+// (e.g. "KeyT.ValST"), or an empty string if it is not known.
+func (err *YDBError) Operation() string {
+	return err.operation
+}
+
+// Params is a method to return the variable name followed by the subscripts of the node the failing operation was passed,
+// or nil if the operation does not act on a node or they are not known.
+func (err *YDBError) Params() []string {
+	return append([]string(nil), err.params...)
+}
+
+// ErrStr is a method to return the contents of the errstr buffer as YottaDB returned it for the error, or an empty string
+// if no errstr buffer was passed or YottaDB did not fill it in.
+func (err *YDBError) ErrStr() string {
+	return err.errstr
+}
+
+// ZStatus is a method to return the value of $ZSTATUS when the error was created, or an empty string if it was not
+// recorded (see EnableErrorZStatus()). Note that $ZSTATUS is shared by all goroutines, so it may describe an error that
+// occurred in another goroutine at about the same time - ErrStr() is specific to the call that failed.
+func (err *YDBError) ZStatus() string {
+	return err.zstatus
+}
+
+// Detail is a method to return a multi-line description of the error for diagnosis: the error message followed by the
+// failing operation, the node it was passed (in ZWRITE format), the errstr contents and $ZSTATUS, each when known. Errors
+// such as INVVARNAME and INVSTRLEN can then be diagnosed from a log without rerunning the application.
+func (err *YDBError) Detail() string {
+	var detail strings.Builder
+
+	detail.WriteString(err.errmsg)
+	if "" != err.operation {
+		fmt.Fprintf(&detail, "\n  Operation: %s", err.operation)
+	}
+	if 0 < len(err.params) {
+		fmt.Fprintf(&detail, "\n  Node: %s", ZwriteName(err.params[0], err.params[1:]))
+	}
+	if "" != err.errstr {
+		fmt.Fprintf(&detail, "\n  errstr: %s", err.errstr)
+	}
+	if "" != err.zstatus {
+		fmt.Fprintf(&detail, "\n  $ZSTATUS: %s", err.zstatus)
+	}
+	return detail.String()
+}
+
+// Sentinel errors for use with errors.Is(). Each matches any YDBError with the same error code, whatever its message.
+var (
+	ErrGVUNDEF        error = &YDBError{errcode: (int)(YDB_ERR_GVUNDEF), errmsg: "GVUNDEF"}
+	ErrLVUNDEF        error = &YDBError{errcode: (int)(YDB_ERR_LVUNDEF), errmsg: "LVUNDEF"}
+	ErrNODEEND        error = &YDBError{errcode: (int)(YDB_ERR_NODEEND), errmsg: "NODEEND"}
+	ErrTPRESTART      error = &YDBError{errcode: YDB_TP_RESTART, errmsg: "TPRESTART"}
+	ErrTPROLLBACK     error = &YDBError{errcode: YDB_TP_ROLLBACK, errmsg: "ROLLBACK"}
+	ErrTPTIMEOUT      error = &YDBError{errcode: (int)(YDB_ERR_TPTIMEOUT), errmsg: "TPTIMEOUT"}
+	ErrLOCKTIMEOUT    error = &YDBError{errcode: YDB_LOCK_TIMEOUT, errmsg: "LOCKTIMEOUT"}
+	ErrINVSTRLEN      error = &YDBError{errcode: (int)(YDB_ERR_INVSTRLEN), errmsg: "INVSTRLEN"}
+	ErrCALLINAFTERXIT error = &YDBError{errcode: (int)(YDB_ERR_CALLINAFTERXIT), errmsg: "CALLINAFTERXIT"}
+	ErrNESTEDTPTOKEN  error = &YDBError{errcode: (int)(YDB_ERR_NESTEDTPTOKEN), errmsg: "NESTEDTPTOKEN"}
+)
+
+// ydbErrorHints is a map of hints on how to deal with the errors most commonly seen by Go applications, indexed by mnemonic
+var ydbErrorHints = map[string]string{
+	"GVUNDEF":        "The global variable node has no value - use DataE() or DataST() to check whether a node exists",
+	"LVUNDEF":        "The local variable node has no value - use DataE() or DataST() to check whether a node exists",
+	"INVSTRLEN":      "A BufferT is too small for the value returned - allocate a larger buffer and retry",
+	"TPTIMEOUT":      "The transaction ran longer than $ZMAXTPTIME allows - see MaxTPTimeE() and SetMaxTPTimeE()",
+	"CALLINAFTERXIT": "YottaDB has already been shut down by Exit() so no further calls can be made",
+	"NESTEDTPTOKEN":  "Pass the tptoken given to the transaction closure rather than NOTTP, or see SetImplicitTPToken()",
+}
+
+// ydbErrorDocURL is the URL of the documentation of YottaDB error messages, with anchors that are the lower case mnemonic
+const ydbErrorDocURL string = "https://docs.yottadb.com/MessageRecovery/errors.html#"
+
+// ExplainError is a function to expand an error into a multi-line description suitable for application logs. The first
+// line is the error message (including any context added by wrapping the error with fmt.Errorf()). For an error that is
+// or wraps a YDBError, further lines give the mnemonic and error code, a hint on how to deal with the error for commonly
+// seen errors, and the URL of the YottaDB documentation of the error. Wrapper errors and the return codes that are not
+// errors (such as YDB_TP_RESTART) have no documentation URL. A nil err returns an empty string.
+func ExplainError(err error) string {
+	var yerr *YDBError
+	var explanation strings.Builder
+
+	if nil == err {
+		return ""
+	}
+	explanation.WriteString(err.Error())
+	if !errors.As(err, &yerr) {
+		return explanation.String()
+	}
+	mnemonic := yerr.Mnemonic()
+	if "" != mnemonic {
+		fmt.Fprintf(&explanation, "\n  Error: %s (%d)", mnemonic, yerr.errcode)
+	} else {
+		fmt.Fprintf(&explanation, "\n  Error: %d", yerr.errcode)
+	}
+	if hint, ok := ydbErrorHints[mnemonic]; ok {
+		fmt.Fprintf(&explanation, "\n  Hint: %s", hint)
+	}
+	// Only engine errors (GT.M and YDB facilities) are documented
+	facility := (uint32(-yerr.errcode) >> 16) & 0x7ff
+	if "" != mnemonic && 0 > yerr.errcode && (246 == facility || 256 == facility) {
+		fmt.Fprintf(&explanation, "\n  Documentation: %s%s", ydbErrorDocURL, strings.ToLower(mnemonic))
+	}
+	return explanation.String()
+}
+
+// ErrorCode is a function used to find the error return code. If err is not a YDBError and does not wrap one, -1 is
+// returned.
 func ErrorCode(err error) int {
-	yerr, ok := err.(*YDBError)
-	if ok {
-		rc := yerr.errcode
-		return rc
+	var yerr *YDBError
+
+	if errors.As(err, &yerr) {
+		return yerr.errcode
 	}
 	return -1
 }
@@ -87,27 +252,33 @@ func NewError(tptoken uint64, errstr *BufferT, errnum int) error {
 
 	if YDB_TP_RESTART == errnum {
 		// Shortcut for this performance sensitive error - not a user error
-		return &YDBError{errnum, "TPRESTART"}
+		return &YDBError{errcode: errnum, errmsg: "TPRESTART"}
 	}
 	if YDB_ERR_NODEEND == errnum {
 		// Another common "error" that needs no message
-		return &YDBError{errnum, "NODEEND"}
+		return &YDBError{errcode: errnum, errmsg: "NODEEND"}
 	}
 	if YDB_TP_ROLLBACK == errnum {
 		// Our 3rd and final quickie-check that needs no message
-		return &YDBError{errnum, "ROLLBACK"}
+		return &YDBError{errcode: errnum, errmsg: "ROLLBACK"}
+	}
+	if YDB_LOCK_TIMEOUT == errnum {
+		// Not an error either, and its code has no message facility so MessageT() would panic looking it up
+		return &YDBError{errcode: errnum, errmsg: "LOCKTIMEOUT"}
 	}
 	if (nil != errstr) && (nil != errstr.getCPtr()) {
 		errmsg = C.GoString((*C.char)(errstr.getCPtr().buf_addr))
 	}
+	rawErrstr := errmsg
 	if 0 == len(errmsg) {
 		// No message was supplied for the error - see if we can find one via MessageT()
 		errmsg, err = MessageT(tptoken, errstr, errnum)
 		if nil != err {
-			if YDB_ERR_CALLINAFTERXIT != ErrorCode(err) {
+			if code := ErrorCode(err); YDB_ERR_CALLINAFTERXIT != code && YDB_ERR_NESTEDTPTOKEN != code {
 				panic(fmt.Sprintf("YDB: Unable to find error text for error code %d with error %s", errnum, err))
 			}
-			// We had a CALLINAFTERXIT error from MessageT() - treat it as a nil message
+			// We had a CALLINAFTERXIT error from MessageT(), or NESTEDTPTOKEN as we were passed NOTTP within a
+			// transaction closure - treat it as a nil message
 			errmsg = ""
 		}
 		if 0 == len(errmsg) {
@@ -118,10 +289,10 @@ func NewError(tptoken uint64, errstr *BufferT, errnum int) error {
 			// filenames, etc.
 			//
 			// Note that MessageT() would have taken care of making sure the engine is initialized before this call.
-			msgptr = (*C.char)(allocMem(C.size_t(YDB_MAX_ERRORMSG)))
+			msgptr = (*C.char)(allocMem(C.size_t(YDB_MAX_ERRORMSG), memCatMessages))
 			C.ydb_zstatus(msgptr, C.int(YDB_MAX_ERRORMSG))
 			errmsg = C.GoString((*C.char)(msgptr))
-			freeMem(unsafe.Pointer(msgptr), C.size_t(YDB_MAX_ERRORMSG))
+			freeMem(unsafe.Pointer(msgptr), C.size_t(YDB_MAX_ERRORMSG), memCatMessages)
 			if 0 == len(errmsg) {
 				// We couldn't find text to do with this message so at least make sure we know what the error
 				// code is.
@@ -129,7 +300,49 @@ func NewError(tptoken uint64, errstr *BufferT, errnum int) error {
 			}
 		}
 	}
-	return &YDBError{errnum, errmsg}
+	return &YDBError{errcode: errnum, errmsg: errmsg, errstr: rawErrstr}
+}
+
+var zstatusEnabled uint32 // Atomic: Set to 1 by EnableErrorZStatus(true) to record $ZSTATUS in errors from YottaDB
+
+// EnableErrorZStatus is a function to start (enable true) or stop (enable false) recording the value of $ZSTATUS in the
+// errors returned by calls into YottaDB, for ZStatus() and Detail(). Recording costs a call to ydb_zstatus() and a copy of
+// the message for each error, including errors such as GVUNDEF that applications often expect, so it is disabled by
+// default. Return codes that are not errors, such as NODEEND and YDB_TP_RESTART, and errors made by the wrapper or by
+// NewError() never record $ZSTATUS.
+func EnableErrorZStatus(enable bool) {
+	if enable {
+		atomic.StoreUint32(&zstatusEnabled, 1)
+	} else {
+		atomic.StoreUint32(&zstatusEnabled, 0)
+	}
+}
+
+// engineError is a function to create the error for the return code rc of a call into YottaDB made by the given wrapper
+// function or method (e.g. "KeyT.ValST"), recording the operation and, if enabled by EnableErrorZStatus(), $ZSTATUS.
+func engineError(tptoken uint64, errstr *BufferT, rc int, operation string) error {
+	err := NewError(tptoken, errstr, rc)
+	yerr, ok := err.(*YDBError)
+	if !ok {
+		return err
+	}
+	yerr.operation = operation
+	if 0 > rc && YDB_ERR_NODEEND != rc && YDB_ERR_CALLINAFTERXIT != rc && 1 == atomic.LoadUint32(&zstatusEnabled) {
+		msgptr := (*C.char)(allocMem(C.size_t(YDB_MAX_ERRORMSG), memCatMessages))
+		C.ydb_zstatus(msgptr, C.int(YDB_MAX_ERRORMSG))
+		yerr.zstatus = C.GoString(msgptr)
+		freeMem(unsafe.Pointer(msgptr), C.size_t(YDB_MAX_ERRORMSG), memCatMessages)
+	}
+	return err
+}
+
+// withOperation is a function to attribute err, if it is an error from a call into YottaDB, to the given Easy API function
+// (e.g. "ValE") rather than to the method of KeyT or BufferTArray it made the call through, and return it.
+func withOperation(err error, operation string) error {
+	if yerr, ok := err.(*YDBError); ok && "" != yerr.operation {
+		yerr.operation = operation
+	}
+	return err
 }
 
 // getWrapperErrorMsg fetches returns a message string containing a formatted-as-error local message given its error number
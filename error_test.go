@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -14,8 +14,10 @@ package yottadb_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"lang.yottadb.com/go/yottadb"
+	"strings"
 	"testing"
 )
 
@@ -58,3 +60,87 @@ func TestErrorVerifyFastPathErrorCodes(t *testing.T) {
 	verifyErrorCode(t, yottadb.YDB_TP_ROLLBACK)
 	verifyErrorCode(t, yottadb.YDB_ERR_NODEEND)
 }
+
+func TestErrorCodeMnemonicIs(t *testing.T) {
+	_, err := yottadb.ValE(yottadb.NOTTP, nil, "^undefinedglobal", []string{"x"})
+	var yerr *yottadb.YDBError
+	assert.True(t, errors.As(err, &yerr))
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yerr.Code())
+	assert.Equal(t, "GVUNDEF", yerr.Mnemonic())
+	assert.True(t, errors.Is(err, yottadb.ErrGVUNDEF))
+	assert.False(t, errors.Is(err, yottadb.ErrLVUNDEF))
+	// Wrapped errors are found by errors.Is() and ErrorCode()
+	wrapped := fmt.Errorf("fetching customer: %w", err)
+	assert.True(t, errors.Is(wrapped, yottadb.ErrGVUNDEF))
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(wrapped))
+	// Mnemonics of wrapper errors and of the errors with shortcut messages
+	err = yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_ERR_INVLKNMPAIRLIST)
+	assert.Equal(t, "INVLKNMPAIRLIST", err.(*yottadb.YDBError).Mnemonic())
+	assert.True(t, strings.HasPrefix(err.Error(), "%YDB-E-INVLKNMPAIRLIST, "))
+	err = yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_ERR_STRUCTUNALLOCD)
+	assert.Equal(t, "STRUCTUNALLOCD", err.(*yottadb.YDBError).Mnemonic())
+	assert.True(t, strings.HasPrefix(err.Error(), "%YDB-E-STRUCTUNALLOCD, "))
+	err = yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_TP_RESTART)
+	assert.Equal(t, "TPRESTART", err.(*yottadb.YDBError).Mnemonic())
+	assert.True(t, errors.Is(err, yottadb.ErrTPRESTART))
+}
+
+func TestErrorExplainError(t *testing.T) {
+	_, err := yottadb.ValE(yottadb.NOTTP, nil, "^undefinedglobal", []string{"x"})
+	explanation := yottadb.ExplainError(fmt.Errorf("fetching customer: %w", err))
+	lines := strings.Split(explanation, "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "fetching customer: "+err.Error(), lines[0])
+	assert.Equal(t, fmt.Sprintf("  Error: GVUNDEF (%d)", yottadb.YDB_ERR_GVUNDEF), lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "  Hint: "))
+	assert.Equal(t, "  Documentation: https://docs.yottadb.com/MessageRecovery/errors.html#gvundef", lines[3])
+	// Wrapper errors have no documentation and other errors are returned as-is
+	explanation = yottadb.ExplainError(yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_ERR_INVLKNMPAIRLIST))
+	assert.Equal(t, 2, len(strings.Split(explanation, "\n")))
+	assert.Equal(t, "plain error", yottadb.ExplainError(errors.New("plain error")))
+	assert.Equal(t, "", yottadb.ExplainError(nil))
+}
+
+func TestNewErrorLockTimeout(t *testing.T) {
+	// YDB_LOCK_TIMEOUT is a return code rather than a message id, so NewError() must not look up its message
+	err := yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_LOCK_TIMEOUT)
+	assert.Equal(t, yottadb.YDB_LOCK_TIMEOUT, yottadb.ErrorCode(err))
+	assert.Equal(t, "LOCKTIMEOUT", err.Error())
+	assert.True(t, errors.Is(err, yottadb.ErrLOCKTIMEOUT))
+}
+
+func TestErrorDetail(t *testing.T) {
+	var errstr yottadb.BufferT
+	var yerr *yottadb.YDBError
+
+	errstr.Alloc(yottadb.YDB_MAX_ERRORMSG)
+	defer errstr.Free()
+	yottadb.EnableErrorZStatus(true)
+	_, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^1bad", []string{"a", "1"})
+	yottadb.EnableErrorZStatus(false)
+	assert.True(t, errors.As(err, &yerr))
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yerr.Code())
+	assert.Equal(t, "ValE", yerr.Operation())
+	assert.Equal(t, []string{"^1bad", "a", "1"}, yerr.Params())
+	assert.Equal(t, err.Error(), yerr.ErrStr())
+	assert.Contains(t, yerr.ZStatus(), "INVVARNAME")
+	detail := strings.Split(yerr.Detail(), "\n")
+	assert.Equal(t, []string{err.Error(), "  Operation: ValE", `  Node: ^1bad("a",1)`, "  errstr: " + err.Error(),
+		"  $ZSTATUS: " + yerr.ZStatus()}, detail)
+	// $ZSTATUS is only recorded when enabled, and methods report their own name
+	var key yottadb.KeyT
+	key.Alloc(8, 1, 8)
+	defer key.Free()
+	assert.Nil(t, key.Varnm.SetValStr(yottadb.NOTTP, nil, "^1bad"))
+	assert.True(t, errors.As(key.SetValST(yottadb.NOTTP, nil, &errstr), &yerr))
+	assert.Equal(t, "KeyT.SetValST", yerr.Operation())
+	assert.Equal(t, "", yerr.ZStatus())
+	// Errors made with NewError(), such as those of fault hooks, record neither
+	yerr = yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_ERR_GVUNDEF).(*yottadb.YDBError)
+	assert.Equal(t, "", yerr.Operation())
+	assert.Equal(t, "", yerr.ZStatus())
+	// Return codes that are not errors carry no parameters
+	_, err = yottadb.SubNextE(yottadb.NOTTP, &errstr, "^undefinedglobal", []string{""})
+	assert.True(t, errors.As(err, &yerr))
+	assert.Nil(t, yerr.Params())
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -23,16 +23,39 @@ const (
 	YDB_ERR_DBRNDWNBYPASS   = -151552026
 	YDB_ERR_SIGACKTIMEOUT   = -151552034
 	YDB_ERR_SIGGORTNTIMEOUT = -151552040
+	YDB_ERR_INVZWRSTR       = -151552050
+	YDB_ERR_INVUTF8STR      = -151552058
+	YDB_ERR_INVKEYENC       = -151552066
+	YDB_ERR_INVTUNABLE      = -151552074
+	YDB_ERR_NESTEDTPTOKEN   = -151552082
+	YDB_ERR_INVCALLINTAB    = -151552090
+	YDB_ERR_CALLMTRUNC      = -151552098
+	YDB_ERR_TLEVELCHANGE    = -151552106
+	YDB_ERR_INVJSONPATH     = -151552114
+	YDB_ERR_INVCHUNKPROG    = -151552122
+	YDB_ERR_INVDUMPFMT      = -151552130
 )
 
 // ydbGoErrors is an array of error entries containing the Go-only set of errors
 var ydbGoErrors = []ydbGoErrEntry{
-	{-YDB_ERR_STRUCTUNALLOCD, "E", "STRUCTNUNALLOCD", "Structure not previously called with Alloc() method"},
-	{-YDB_ERR_INVLKNMPAIRLIST, "E", "INVLKNMPAIRLIST",
+	{-YDB_ERR_STRUCTUNALLOCD, "STRUCTUNALLOCD", "E", "Structure not previously called with Alloc() method"},
+	{-YDB_ERR_INVLKNMPAIRLIST, "INVLKNMPAIRLIST", "E",
 		"Invalid lockname/subscript pair list (uneven number of lockname/subscript parameters)"},
-	{-YDB_ERR_DBRNDWNBYPASS, "DBRNDWNBYPASS", "E",
-		"YDB-W-DBRNDWNBYPASS YottaDB database rundown may have been bypassed due to timeout - run MUPIP JOURNAL ROLLBACK" +
+	{-YDB_ERR_DBRNDWNBYPASS, "DBRNDWNBYPASS", "W",
+		"YottaDB database rundown may have been bypassed due to timeout - run MUPIP JOURNAL ROLLBACK" +
 			" BACKWARD / MUPIP JOURNAL RECOVER BACKWARD / MUPIP RUNDOWN"},
 	{-YDB_ERR_SIGACKTIMEOUT, "SIGACKTIMEOUT", "E", "Signal completion acknowledgement timeout: !AD"},
-	{-YDB_ERR_SIGGORTNTIMEOUT, "ERR_SIGGORTNTIMEOUT", "W", "Shutdown of signal goroutines timed out"},
+	{-YDB_ERR_SIGGORTNTIMEOUT, "SIGGORTNTIMEOUT", "W", "Shutdown of signal goroutines timed out"},
+	{-YDB_ERR_INVZWRSTR, "INVZWRSTR", "E", "String is not in valid ZWRITE format: !AD"},
+	{-YDB_ERR_INVUTF8STR, "INVUTF8STR", "E", "String is not valid UTF-8 as required by UTF-8 mode: !AD"},
+	{-YDB_ERR_INVKEYENC, "INVKEYENC", "E", "String is not a valid encoded key: !AD"},
+	{-YDB_ERR_INVTUNABLE, "INVTUNABLE", "E", "Invalid value for intrinsic special variable: !AD"},
+	{-YDB_ERR_NESTEDTPTOKEN, "NESTEDTPTOKEN", "E",
+		"Call from within a transaction used NOTTP instead of the transaction's tptoken, which would deadlock"},
+	{-YDB_ERR_INVCALLINTAB, "INVCALLINTAB", "E", "Invalid call-in table entry: !AD"},
+	{-YDB_ERR_CALLMTRUNC, "CALLMTRUNC", "E", "Call-in return value truncated to the !AD bytes allowed by retvallen"},
+	{-YDB_ERR_TLEVELCHANGE, "TLEVELCHANGE", "E", "Call-in routine did not leave $TLEVEL as it found it: !AD"},
+	{-YDB_ERR_INVJSONPATH, "INVJSONPATH", "E", "JSON path is not valid or addresses more than one node: !AD"},
+	{-YDB_ERR_INVCHUNKPROG, "INVCHUNKPROG", "E", "Progress node of ChunkedTpE() does not hold a number of items: !AD"},
+	{-YDB_ERR_INVDUMPFMT, "INVDUMPFMT", "E", "Invalid DumpTreeE() output format: !AD"},
 }
@@ -0,0 +1,48 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"sync"
+)
+
+var faultMutex sync.Mutex                                 // Protects faultHook
+var faultHook func(tptoken uint64, op, node string) error // Hook set by SetFaultHook()
+
+// SetFaultHook is a function for resilience tests to inject faults into the database operations of the process, or to stop
+// if hook is nil. The hook is called before each operation traced by TraceOps() (see there for the names of operations),
+// with the tptoken of the operation, the name of the operation and the name of the node in ZWRITE format. If the hook
+// returns an error, the operation returns that error without calling YottaDB; the hook may also sleep to add latency. Use
+// NewError() with the tptoken passed to the hook to return errors that YottaDB could return, such as YDB_TP_RESTART within
+// a transaction, YDB_LOCK_TIMEOUT or YDB_ERR_GVUNDEF. The chaos package provides a hook that injects random faults.
+//
+// The hook is called on the goroutine making the operation, and may be called from multiple goroutines at once. When no
+// hook is set, the cost is one atomic load per operation.
+func SetFaultHook(hook func(tptoken uint64, op, node string) error) {
+	printEntry("SetFaultHook()")
+	faultMutex.Lock()
+	defer faultMutex.Unlock()
+	faultHook = hook
+	setHook(hookFault, nil != hook)
+}
+
+// injectFault is a function to call the hook set by SetFaultHook() for an operation on the named node and return its error
+func injectFault(tptoken uint64, op, node string) error {
+	faultMutex.Lock()
+	hook := faultHook
+	faultMutex.Unlock()
+	if nil == hook {
+		return nil
+	}
+	return hook(tptoken, op, node)
+}
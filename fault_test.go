@@ -0,0 +1,44 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestSetFaultHook(t *testing.T) {
+	var ops []string
+
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "value", "^faulthook", []string{"a"}), t)
+	yottadb.SetFaultHook(func(tptoken uint64, op, node string) error {
+		if `^faulthook("a")` != node {
+			return nil
+		}
+		ops = append(ops, op)
+		return yottadb.NewError(tptoken, nil, yottadb.YDB_LOCK_TIMEOUT)
+	})
+	_, err := yottadb.ValE(yottadb.NOTTP, nil, "^faulthook", []string{"a"})
+	assert.Equal(t, yottadb.YDB_LOCK_TIMEOUT, yottadb.ErrorCode(err))
+	err = yottadb.SetValE(yottadb.NOTTP, nil, "other", "^faulthook", []string{"a"})
+	assert.Equal(t, yottadb.YDB_LOCK_TIMEOUT, yottadb.ErrorCode(err))
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "other", "^faulthook", []string{"b"}), t)
+	assert.Equal(t, []string{"Get", "Set"}, ops)
+	yottadb.SetFaultHook(nil)
+
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^faulthook", []string{"a"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "value", val)
+}
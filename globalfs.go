@@ -0,0 +1,346 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GlobalFS is a structure that presents the tree of a global or local variable node as a read-only file system (an
+// io/fs.FS), so the standard library and other packages that read files, such as archive/zip, net/http.FileServer and
+// html/template, can read content stored in the database. For example, with
+//
+//	^site("static","index.html")="<html>...</html>"
+//	^site("static","css","site.css")="body {...}"
+//
+// NewGlobalFS("^site", []string{"static"}) is a file system with a file index.html and a directory css containing a file
+// site.css. A path maps to the node whose subscripts, below those of the root, are the elements of the path. A node with
+// descendants is a directory; a node with a value and no descendants is a file whose contents are the value. The value of a
+// node that has both is not accessible. Subscripts that cannot be path elements (the empty string, "." and "..", and
+// subscripts containing "/") are omitted from directory listings and cannot be opened.
+//
+// A GlobalFS accesses the database with NOTTP as it has no way to receive a tptoken, so it cannot be used within a
+// transaction. Reading a file fetches its complete value when the file is opened.
+type GlobalFS struct {
+	varname string   // Variable name of the root node
+	subary  []string // Subscripts of the root node
+}
+
+// globalFSFile is a structure for an open file or directory of a GlobalFS
+type globalFSFile struct {
+	info    globalFSInfo    // Information about the file
+	reader  *strings.Reader // Contents of a file
+	entries []fs.DirEntry   // Entries of a directory not yet returned by ReadDir()
+	fsys    *GlobalFS       // File system of the file
+	subary  []string        // Subscripts of the node of a directory (below those of the root)
+	listed  bool            // Whether entries has been filled in for a directory
+}
+
+// globalFSInfo is a structure implementing fs.FileInfo and fs.DirEntry for a node of a GlobalFS
+type globalFSInfo struct {
+	name  string // Name of the file (last path element)
+	size  int64  // Length of the value of a file
+	isDir bool   // Whether the node is a directory
+}
+
+// globalFSEntry is a structure implementing fs.DirEntry for a node of a GlobalFS, fetching its size only when needed
+type globalFSEntry struct {
+	fsys   *GlobalFS // File system of the entry
+	name   string    // Name of the entry
+	subary []string  // Subscripts of the node (below those of the root)
+	isDir  bool      // Whether the node is a directory
+}
+
+// NewGlobalFS is a function to return a GlobalFS for the tree of varname(subary...).
+func NewGlobalFS(varname string, subary []string) *GlobalFS {
+	return &GlobalFS{varname: varname, subary: append([]string(nil), subary...)}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// io/fs interface methods of GlobalFS
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Open is a method to open the named file or directory, implementing fs.FS.
+func (fsys *GlobalFS) Open(name string) (fs.File, error) {
+	printEntry("GlobalFS.Open()")
+	subary, ok := globalFSSubscripts(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrInvalid}
+	}
+	isDir, err := fsys.isDir("open", name, subary)
+	if nil != err {
+		return nil, err
+	}
+	file := globalFSFile{info: globalFSInfo{name: globalFSBase(name), isDir: isDir}, fsys: fsys, subary: subary}
+	if !isDir {
+		value, err := ValE(NOTTP, nil, fsys.varname, fsys.fullSubscripts(subary))
+		if nil != err {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		file.reader = strings.NewReader(value)
+		file.info.size = int64(len(value))
+	}
+	return &file, nil
+}
+
+// ReadDir is a method to return the entries of the named directory sorted by name, implementing fs.ReadDirFS.
+func (fsys *GlobalFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	printEntry("GlobalFS.ReadDir()")
+	subary, ok := globalFSSubscripts(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	isDir, err := fsys.isDir("readdir", name, subary)
+	if nil != err {
+		return nil, err
+	}
+	if !isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrInvalid}
+	}
+	entries, err := fsys.list(subary)
+	if nil != err {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+// ReadFile is a method to return the contents of the named file, implementing fs.ReadFileFS.
+func (fsys *GlobalFS) ReadFile(name string) ([]byte, error) {
+	printEntry("GlobalFS.ReadFile()")
+	subary, ok := globalFSSubscripts(name)
+	if !ok {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	isDir, err := fsys.isDir("readfile", name, subary)
+	if nil != err {
+		return nil, err
+	}
+	if isDir {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: fs.ErrInvalid}
+	}
+	value, err := ValE(NOTTP, nil, fsys.varname, fsys.fullSubscripts(subary))
+	if nil != err {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return []byte(value), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Methods of open files, file information and directory entries
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Stat is a method to return information about the file, implementing fs.File.
+func (file *globalFSFile) Stat() (fs.FileInfo, error) {
+	return file.info, nil
+}
+
+// Read is a method to read the contents of the file, implementing fs.File.
+func (file *globalFSFile) Read(buf []byte) (int, error) {
+	if file.info.isDir {
+		return 0, &fs.PathError{Op: "read", Path: file.info.name, Err: fs.ErrInvalid}
+	}
+	return file.reader.Read(buf)
+}
+
+// Seek is a method to set the offset for the next Read() of the file, implementing io.Seeker (for
+// net/http.FileServer).
+func (file *globalFSFile) Seek(offset int64, whence int) (int64, error) {
+	if file.info.isDir {
+		return 0, &fs.PathError{Op: "seek", Path: file.info.name, Err: fs.ErrInvalid}
+	}
+	return file.reader.Seek(offset, whence)
+}
+
+// ReadAt is a method to read the contents of the file at the given offset, implementing io.ReaderAt.
+func (file *globalFSFile) ReadAt(buf []byte, offset int64) (int, error) {
+	if file.info.isDir {
+		return 0, &fs.PathError{Op: "read", Path: file.info.name, Err: fs.ErrInvalid}
+	}
+	return file.reader.ReadAt(buf, offset)
+}
+
+// Close is a method to close the file, implementing fs.File.
+func (file *globalFSFile) Close() error {
+	return nil
+}
+
+// ReadDir is a method to return the next count entries of the directory (all remaining entries if count <= 0),
+// implementing fs.ReadDirFile.
+func (file *globalFSFile) ReadDir(count int) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+
+	if !file.info.isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: file.info.name, Err: fs.ErrInvalid}
+	}
+	if !file.listed {
+		list, err := file.fsys.list(file.subary)
+		if nil != err {
+			return nil, &fs.PathError{Op: "readdir", Path: file.info.name, Err: err}
+		}
+		file.entries = list
+		file.listed = true
+	}
+	if 0 >= count || count > len(file.entries) {
+		entries = file.entries
+		file.entries = nil
+		if 0 < count && 0 == len(entries) {
+			return nil, io.EOF
+		}
+		return entries, nil
+	}
+	entries = file.entries[:count]
+	file.entries = file.entries[count:]
+	return entries, nil
+}
+
+// Name is a method to return the name of the file, implementing fs.FileInfo and fs.DirEntry.
+func (info globalFSInfo) Name() string { return info.name }
+
+// Size is a method to return the length of the value of a file, implementing fs.FileInfo.
+func (info globalFSInfo) Size() int64 { return info.size }
+
+// Mode is a method to return the (read-only) mode of the file, implementing fs.FileInfo.
+func (info globalFSInfo) Mode() fs.FileMode {
+	if info.isDir {
+		return fs.ModeDir | 0555
+	}
+	return 0444
+}
+
+// ModTime is a method to return the modification time of the file, which is not recorded so is the zero time,
+// implementing fs.FileInfo.
+func (info globalFSInfo) ModTime() time.Time { return time.Time{} }
+
+// IsDir is a method to return whether the file is a directory, implementing fs.FileInfo and fs.DirEntry.
+func (info globalFSInfo) IsDir() bool { return info.isDir }
+
+// Sys is a method to return the underlying data source of the file, which is nil, implementing fs.FileInfo.
+func (info globalFSInfo) Sys() interface{} { return nil }
+
+// Name is a method to return the name of the entry, implementing fs.DirEntry.
+func (entry *globalFSEntry) Name() string { return entry.name }
+
+// IsDir is a method to return whether the entry is a directory, implementing fs.DirEntry.
+func (entry *globalFSEntry) IsDir() bool { return entry.isDir }
+
+// Type is a method to return the type bits of the entry, implementing fs.DirEntry.
+func (entry *globalFSEntry) Type() fs.FileMode {
+	if entry.isDir {
+		return fs.ModeDir
+	}
+	return 0
+}
+
+// Info is a method to return information about the entry, implementing fs.DirEntry. The length of a file is fetched
+// when Info() is called.
+func (entry *globalFSEntry) Info() (fs.FileInfo, error) {
+	info := globalFSInfo{name: entry.name, isDir: entry.isDir}
+	if !entry.isDir {
+		value, err := ValE(NOTTP, nil, entry.fsys.varname, entry.fsys.fullSubscripts(entry.subary))
+		if nil != err {
+			return nil, &fs.PathError{Op: "stat", Path: entry.name, Err: err}
+		}
+		info.size = int64(len(value))
+	}
+	return info, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// fullSubscripts is a method to return the subscripts of the root node followed by the given subscripts.
+func (fsys *GlobalFS) fullSubscripts(subary []string) []string {
+	return append(append(make([]string, 0, len(fsys.subary)+len(subary)), fsys.subary...), subary...)
+}
+
+// isDir is a method to return whether the node of the given subscripts is a directory, or an fs.ErrNotExist error for
+// operation op on path name if the node does not exist. The root is always a directory.
+func (fsys *GlobalFS) isDir(op, name string, subary []string) (bool, error) {
+	if 0 == len(subary) {
+		return true, nil
+	}
+	data, err := DataE(NOTTP, nil, fsys.varname, fsys.fullSubscripts(subary))
+	if nil != err {
+		return false, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if 0 == data {
+		return false, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+	return 10 <= data, nil
+}
+
+// list is a method to return the entries of the directory of the given subscripts, sorted by name.
+func (fsys *GlobalFS) list(subary []string) ([]fs.DirEntry, error) {
+	var entries []fs.DirEntry
+
+	full := append(fsys.fullSubscripts(subary), "")
+	last := len(full) - 1
+	for {
+		sub, err := SubNextE(NOTTP, nil, fsys.varname, full)
+		if nil != err {
+			if int(YDB_ERR_NODEEND) == ErrorCode(err) {
+				break
+			}
+			return nil, err
+		}
+		full[last] = sub
+		if !globalFSValidName(sub) {
+			continue
+		}
+		data, err := DataE(NOTTP, nil, fsys.varname, full)
+		if nil != err {
+			return nil, err
+		}
+		if 0 == data { // Deleted since SubNextE() returned it
+			continue
+		}
+		entrySubary := append(append([]string(nil), subary...), sub)
+		entries = append(entries, &globalFSEntry{fsys: fsys, name: sub, subary: entrySubary, isDir: 10 <= data})
+	}
+	// Subscripts are in M collation order (e.g. 2 before 10), but fs.ReadDirFS requires entries sorted by name
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+	return entries, nil
+}
+
+// globalFSSubscripts is a function to return the subscripts (below those of the root) of the node of the given path, or
+// false if the path is not valid.
+func globalFSSubscripts(name string) ([]string, bool) {
+	if !fs.ValidPath(name) {
+		return nil, false
+	}
+	if "." == name {
+		return []string{}, true
+	}
+	return strings.Split(name, "/"), true
+}
+
+// globalFSBase is a function to return the last element of the given valid path.
+func globalFSBase(name string) string {
+	return name[strings.LastIndexByte(name, '/')+1:]
+}
+
+// globalFSValidName is a function to return whether the given subscript can be a path element.
+func globalFSValidName(sub string) bool {
+	return "" != sub && "." != sub && ".." != sub && !strings.Contains(sub, "/")
+}
@@ -0,0 +1,52 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+	"testing/fstest"
+)
+
+func TestGlobalFS(t *testing.T) {
+	nodes := map[string][]string{
+		"<html></html>": {"static", "index.html"},
+		"body {}":       {"static", "css", "site.css"},
+		"ten":           {"static", "10"},
+		"two":           {"static", "2"},
+		"hidden":        {"static", "a/b"},
+	}
+	for value, subary := range nodes {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, value, "^globalfs", subary), t)
+	}
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^globalfs", []string{})
+	fsys := yottadb.NewGlobalFS("^globalfs", []string{"static"})
+	Assertnoerr(fstest.TestFS(fsys, "index.html", "css/site.css", "10", "2"), t)
+	contents, err := fs.ReadFile(fsys, "css/site.css")
+	Assertnoerr(err, t)
+	assert.Equal(t, "body {}", string(contents))
+	entries, err := fs.ReadDir(fsys, ".")
+	Assertnoerr(err, t)
+	names := []string{}
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	assert.Equal(t, []string{"10", "2", "css", "index.html"}, names) // "a/b" cannot be a path element
+	_, err = fsys.Open("missing.html")
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+	_, err = fsys.Open("../static")
+	assert.ErrorIs(t, err, fs.ErrInvalid)
+}
@@ -0,0 +1,102 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"path"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API functions operating on global variable names
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// GlobalNamesE is an Easy API function to call fn with the name of each global variable in the database, including its
+// caret, whose name without the caret starts with prefix, in collation order. An empty prefix passes every global variable
+// name and a caret at the start of prefix is ignored. Iteration stops early if fn returns false.
+//
+// GlobalNamesE() wraps SubNextE() starting from the prefix itself, so it visits only the global variables that match. As with
+// SubRangeE(), fn may update the database, including deleting the global variable passed to it, as iteration continues from
+// the last name passed to fn.
+//
+// If a database call returns an error, iteration stops and the function returns the error.
+func GlobalNamesE(tptoken uint64, errstr *BufferT, prefix string, fn func(name string) bool) error {
+	printEntry("GlobalNamesE()")
+	prefix = strings.TrimPrefix(prefix, "^")
+	// No global variable name collates before ^%, and any other global variable names with the prefix collate after it
+	name := "^" + selectString("" == prefix, "%", prefix)
+	data, err := DataE(tptoken, errstr, name, []string{})
+	if nil != err {
+		return err
+	}
+	if 0 != data && !fn(name) {
+		return nil
+	}
+	for {
+		next, err := SubNextE(tptoken, errstr, name, []string{})
+		if nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		if !strings.HasPrefix(next[1:], prefix) || !fn(next) {
+			return nil
+		}
+		name = next
+	}
+}
+
+// DeleteGlobalsE is an Easy API function to delete every global variable whose name matches pattern, for example the
+// temporary globals of a session, and return the number of global variables deleted.
+//
+// The pattern is matched against the name without its leading caret, with the syntax of path.Match(): "*" matches any
+// sequence of characters, "?" matches any single character and "[...]" matches a character class, so "TMP*" matches ^TMP,
+// ^TMP1 and ^TMPsession but not ^tmp. A caret at the start of pattern is ignored. DeleteGlobalsE() deletes each global
+// variable with ydb_delete_st() and YDB_DEL_TREE, so each global variable is deleted atomically but the set of deletions is
+// not: a global variable created by another process while DeleteGlobalsE() runs may or may not be deleted. Called within a
+// transaction, all the deletions are part of that transaction.
+//
+// If pattern is malformed, DeleteGlobalsE() returns path.ErrBadPattern without deleting anything. If a database call returns
+// an error, the function stops and returns the number of global variables deleted so far and the error.
+func DeleteGlobalsE(tptoken uint64, errstr *BufferT, pattern string) (int, error) {
+	var deleted int
+	var delerr error
+
+	printEntry("DeleteGlobalsE()")
+	pattern = strings.TrimPrefix(pattern, "^")
+	if _, err := path.Match(pattern, ""); nil != err {
+		return 0, err
+	}
+	// Only names starting with the part of the pattern before any special character can match
+	literal := pattern
+	if i := strings.IndexAny(pattern, "*?[\\"); 0 <= i {
+		literal = pattern[:i]
+	}
+	err := GlobalNamesE(tptoken, errstr, literal, func(name string) bool {
+		if matched, _ := path.Match(pattern, name[1:]); !matched {
+			return true
+		}
+		if delerr = DeleteE(tptoken, errstr, YDB_DEL_TREE, name, []string{}); nil != delerr {
+			return false
+		}
+		deleted++
+		return true
+	})
+	if nil != delerr {
+		return deleted, delerr
+	}
+	return deleted, err
+}
@@ -0,0 +1,79 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"path"
+	"testing"
+)
+
+func TestDeleteGlobalsE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	for _, name := range []string{"^TMP", "^TMP1", "^TMPsession", "^tmp", "^TMKeep"} {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", name, []string{"a"}), t)
+	}
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^tmp", []string{})
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^TMKeep", []string{})
+	deleted, err := yottadb.DeleteGlobalsE(yottadb.NOTTP, &errstr, "^TMP*")
+	Assertnoerr(err, t)
+	assert.Equal(t, 3, deleted)
+	for name, expected := range map[string]uint32{"^TMP": 0, "^TMP1": 0, "^TMPsession": 0, "^tmp": 10, "^TMKeep": 10} {
+		data, err := yottadb.DataE(yottadb.NOTTP, &errstr, name, []string{})
+		Assertnoerr(err, t)
+		assert.Equal(t, expected, data, name)
+	}
+	deleted, err = yottadb.DeleteGlobalsE(yottadb.NOTTP, &errstr, "TMP*")
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, deleted)
+	_, err = yottadb.DeleteGlobalsE(yottadb.NOTTP, &errstr, "TMP[")
+	assert.Equal(t, path.ErrBadPattern, err)
+}
+
+func TestGlobalNamesE(t *testing.T) {
+	var errstr yottadb.BufferT
+	var names []string
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	for _, name := range []string{"^GNames", "^GNamesB", "^GNamesA", "^GNamez"} {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", name, []string{}), t)
+		defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, name, []string{})
+	}
+	collect := func(name string) bool {
+		names = append(names, name)
+		return true
+	}
+	Assertnoerr(yottadb.GlobalNamesE(yottadb.NOTTP, &errstr, "GNames", collect), t)
+	assert.Equal(t, []string{"^GNames", "^GNamesA", "^GNamesB"}, names)
+	names = nil
+	Assertnoerr(yottadb.GlobalNamesE(yottadb.NOTTP, &errstr, "^GNam", collect), t)
+	assert.Equal(t, []string{"^GNames", "^GNamesA", "^GNamesB", "^GNamez"}, names)
+	names = nil
+	Assertnoerr(yottadb.GlobalNamesE(yottadb.NOTTP, &errstr, "", collect), t)
+	assert.Subset(t, names, []string{"^GNames", "^GNamesA", "^GNamesB", "^GNamez"})
+	// Iteration stops when fn returns false
+	names = nil
+	Assertnoerr(yottadb.GlobalNamesE(yottadb.NOTTP, &errstr, "GNames", func(name string) bool {
+		names = append(names, name)
+		return false
+	}), t)
+	assert.Equal(t, []string{"^GNames"}, names)
+	err := yottadb.GlobalNamesE(yottadb.NOTTP, &errstr, "1", collect)
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+}
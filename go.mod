@@ -2,10 +2,13 @@ module lang.yottadb.com/go/yottadb
 
 go 1.18
 
-require github.com/stretchr/testify v1.8.2
+require (
+	github.com/BurntSushi/toml v1.6.0
+	github.com/stretchr/testify v1.8.2
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
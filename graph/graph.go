@@ -0,0 +1,124 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package graph stores directed graphs in YottaDB as adjacency lists, using the collation of subscripts to iterate over the
+edges from a node.
+
+An edge from one node to another is stored in a ^graph(from,to) node (or in another global variable of the application's
+choosing) with the empty string as its value, so the edges from a node are the subscripts under it. Node names are
+subscripts, so cannot be the empty string unless the region allows null subscripts.
+
+For example:
+
+	g := graph.New()
+	err := g.AddEdge(yottadb.NOTTP, nil, "alice", "bob")
+	...
+	err = g.BFS(yottadb.NOTTP, nil, "alice", 2, func(node string, depth int) bool {
+		fmt.Println(node, depth)
+		return true
+	})
+*/
+package graph
+
+import (
+	"lang.yottadb.com/go/yottadb"
+)
+
+// DefaultGlobal is the global variable New() stores edges in
+const DefaultGlobal string = "^graph"
+
+// Graph is a directed graph whose edges are stored in Global(from,to) nodes
+type Graph struct {
+	Global string // Global (or local) variable name holding edges, e.g. "^graph"
+}
+
+// New is a function to return the graph stored in DefaultGlobal
+func New() *Graph {
+	return &Graph{Global: DefaultGlobal}
+}
+
+// AddEdge is a method to add an edge from one node to another. Adding an edge that exists has no effect.
+func (graph *Graph) AddEdge(tptoken uint64, errstr *yottadb.BufferT, from, to string) error {
+	return yottadb.SetValE(tptoken, errstr, "", graph.Global, []string{from, to})
+}
+
+// RemoveEdge is a method to remove the edge from one node to another. Removing an edge that does not exist has no effect.
+func (graph *Graph) RemoveEdge(tptoken uint64, errstr *yottadb.BufferT, from, to string) error {
+	return yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_NODE, graph.Global, []string{from, to})
+}
+
+// HasEdge is a method to return whether there is an edge from one node to another.
+func (graph *Graph) HasEdge(tptoken uint64, errstr *yottadb.BufferT, from, to string) (bool, error) {
+	data, err := yottadb.DataE(tptoken, errstr, graph.Global, []string{from, to})
+	if nil != err {
+		return false, err
+	}
+	return 0 != data%10, nil
+}
+
+// Neighbors is a method to call fn with each node there is an edge to from the given node, in collation order. Iteration
+// stops early if fn returns false.
+//
+// If a database call returns an error, iteration stops and the method returns the error.
+func (graph *Graph) Neighbors(tptoken uint64, errstr *yottadb.BufferT, from string, fn func(to string) bool) error {
+	subary := []string{from, ""}
+	for {
+		next, err := yottadb.SubNextE(tptoken, errstr, graph.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		subary[1] = next
+		if !fn(next) {
+			return nil
+		}
+	}
+}
+
+// BFS is a method to traverse the graph breadth first from the given node, calling fn with each node reached and its
+// depth (the number of edges from start, 0 for start itself), visiting each node once. Nodes more than maxDepth edges from
+// start are not visited; a negative maxDepth does not limit the depth. Traversal stops early if fn returns false.
+//
+// If a database call returns an error, traversal stops and the method returns the error.
+func (graph *Graph) BFS(tptoken uint64, errstr *yottadb.BufferT, start string, maxDepth int,
+	fn func(node string, depth int) bool) error {
+	var next []string
+	var stopped bool
+
+	visited := map[string]bool{start: true}
+	if !fn(start, 0) {
+		return nil
+	}
+	level := []string{start}
+	for depth := 1; 0 < len(level) && (0 > maxDepth || depth <= maxDepth); depth++ {
+		next = nil
+		for _, node := range level {
+			err := graph.Neighbors(tptoken, errstr, node, func(to string) bool {
+				if visited[to] {
+					return true
+				}
+				visited[to] = true
+				next = append(next, to)
+				stopped = !fn(to, depth)
+				return !stopped
+			})
+			if nil != err || stopped {
+				return err
+			}
+		}
+		level = next
+	}
+	return nil
+}
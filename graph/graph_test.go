@@ -0,0 +1,67 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package graph_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"lang.yottadb.com/go/yottadb/graph"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestEdgesAndBFS(t *testing.T) {
+	var neighbors, visited []string
+	var depths []int
+
+	g := graph.New()
+	edges := [][2]string{{"a", "b"}, {"a", "c"}, {"b", "d"}, {"c", "d"}, {"d", "e"}, {"e", "a"}}
+	for _, edge := range edges {
+		Assertnoerr(g.AddEdge(yottadb.NOTTP, nil, edge[0], edge[1]), t)
+	}
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, g.Global, []string{})
+	has, err := g.HasEdge(yottadb.NOTTP, nil, "a", "b")
+	Assertnoerr(err, t)
+	assert.True(t, has)
+	has, err = g.HasEdge(yottadb.NOTTP, nil, "b", "a")
+	Assertnoerr(err, t)
+	assert.False(t, has)
+	Assertnoerr(g.Neighbors(yottadb.NOTTP, nil, "a", func(to string) bool {
+		neighbors = append(neighbors, to)
+		return true
+	}), t)
+	assert.Equal(t, []string{"b", "c"}, neighbors)
+	// The cycle back to "a" does not revisit it, and "e" is beyond the depth limit
+	Assertnoerr(g.BFS(yottadb.NOTTP, nil, "a", 2, func(node string, depth int) bool {
+		visited = append(visited, node)
+		depths = append(depths, depth)
+		return true
+	}), t)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, visited)
+	assert.Equal(t, []int{0, 1, 1, 2}, depths)
+	visited = nil
+	Assertnoerr(g.BFS(yottadb.NOTTP, nil, "a", -1, func(node string, depth int) bool {
+		visited = append(visited, node)
+		return "c" != node
+	}), t)
+	assert.Equal(t, []string{"a", "b", "c"}, visited)
+	Assertnoerr(g.RemoveEdge(yottadb.NOTTP, nil, "a", "b"), t)
+	has, err = g.HasEdge(yottadb.NOTTP, nil, "a", "b")
+	Assertnoerr(err, t)
+	assert.False(t, has)
+}
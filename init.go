@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2020-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2020-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -65,6 +65,12 @@ var ydbSignalList = []syscall.Signal{
 // being the dimension of the ydbShutdownChannel array below that has one slot for each signal handled.
 var signalsHandled = len(ydbSignalList)
 
+// Range of real-time signals (SIGRTMIN to SIGRTMAX as seen by applications) that can be passed through to the user
+const (
+	sigRTMin syscall.Signal = 34
+	sigRTMax syscall.Signal = 64
+)
+
 // This is a map value element used to look up user signal notification channel and flags (one handler per signal)
 type sigNotificationMapEntry struct {
 	notifyChan chan bool      // Channel for user to be notified of a signal being received.
@@ -85,6 +91,15 @@ var sigNotificationMapMutex sync.Mutex // Mutex for access to user sig handler m
 var ydbSignalActive []uint32           // Array of indicators indicating indexed signal handlers are active
 var ydbShutdownComplete []uint32       // Array of flags that indexed signal goroutine shutdown is complete
 
+// This is a map value element used to look up the channels of a signal passed through to the user by RegisterSignalPassThrough()
+type sigPassThroughMapEntry struct {
+	sigChan    chan os.Signal // Channel the wrapper receives the signal on from the Go runtime
+	notifyChan chan os.Signal // Channel the user receives the signal on
+}
+
+var sigPassThroughMap map[syscall.Signal]sigPassThroughMapEntry
+var sigPassThroughMapMutex sync.Mutex // Mutex for access to sigPassThroughMap
+
 // validateNotifySignal verifies that the specified signal is valid for RegisterSignalNotify()/UnregisterSignalNotify()
 func validateNotifySignal(sig syscall.Signal, entryPoint ydbEntryPoint) error {
 	// Verify the supplied signal is one that we support with this function. Note this list contains all of the signals
@@ -160,6 +175,83 @@ func UnRegisterSignalNotify(sig syscall.Signal) error {
 	return nil
 }
 
+// validatePassThroughSignal verifies that the specified signal is valid for
+// RegisterSignalPassThrough()/UnRegisterSignalPassThrough()
+func validatePassThroughSignal(sig syscall.Signal, entryPoint string) {
+	// Only signals that neither the wrapper nor YottaDB handle can be passed through. These are SIGUSR2, SIGWINCH and
+	// the real-time signals. Note the first real-time signals (32 and 33) are reserved by glibc for thread management so
+	// SIGRTMIN as seen by applications is 34.
+	switch {
+	case syscall.SIGUSR2 == sig:
+	case syscall.SIGWINCH == sig:
+	case sigRTMin <= sig && sigRTMax >= sig:
+	default:
+		panic(fmt.Sprintf("YDB: The specified signal (%v) is not supported for signal pass-through by %s", sig, entryPoint))
+	}
+}
+
+// RegisterSignalPassThrough is a function to request that the given signal, which is not one that YottaDB handles, be
+// passed through to the supplied channel. The signals supported are SIGUSR2, SIGWINCH and the real-time signals from
+// SIGRTMIN (34) to SIGRTMAX (64), specified as syscall.Signal(34+n) for SIGRTMIN+n. Any other signal panics - signals that
+// YottaDB handles should use RegisterSignalNotify() instead. Registering a signal already registered replaces its channel.
+//
+// As with signal.Notify(), the wrapper does not block sending to notifyChan so a signal is dropped if the channel is not
+// ready to receive it. The channel should be buffered. Routing these signals through the wrapper rather than calling
+// signal.Notify() directly keeps all the signal handling of a process that uses YottaDB in one place and lets the wrapper
+// stop passing them through when it shuts down.
+func RegisterSignalPassThrough(sig syscall.Signal, notifyChan chan os.Signal) error {
+	if 1 != atomic.LoadUint32(&ydbInitialized) {
+		initializeYottaDB()
+	}
+	validatePassThroughSignal(sig, "yottadb.RegisterSignalPassThrough()")
+	if nil == notifyChan {
+		panic("YDB: The notification channel passed to yottadb.RegisterSignalPassThrough() cannot be nil")
+	}
+	sigPassThroughMapMutex.Lock()
+	defer sigPassThroughMapMutex.Unlock()
+	if nil == sigPassThroughMap {
+		sigPassThroughMap = make(map[syscall.Signal]sigPassThroughMapEntry)
+	}
+	if entry, ok := sigPassThroughMap[sig]; ok {
+		stopSignalPassThrough(entry)
+	}
+	entry := sigPassThroughMapEntry{make(chan os.Signal, 1), notifyChan}
+	sigPassThroughMap[sig] = entry
+	signal.Notify(entry.sigChan, sig)
+	go func() {
+		for sig := range entry.sigChan {
+			select {
+			case entry.notifyChan <- sig:
+			default: // User not ready for the signal - drop it as signal.Notify() would
+				if dbgSigHandling {
+					fmt.Fprintln(os.Stderr, "YDB: RegisterSignalPassThrough: Dropped signal", sig)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// UnRegisterSignalPassThrough removes a pass-through request for the given signal, which reverts to its default handling.
+// No error is raised if the signal did not already have a pass-through request in effect.
+func UnRegisterSignalPassThrough(sig syscall.Signal) error {
+	validatePassThroughSignal(sig, "yottadb.UnRegisterSignalPassThrough()")
+	sigPassThroughMapMutex.Lock()
+	defer sigPassThroughMapMutex.Unlock()
+	if entry, ok := sigPassThroughMap[sig]; ok {
+		stopSignalPassThrough(entry)
+		delete(sigPassThroughMap, sig)
+	}
+	return nil
+}
+
+// stopSignalPassThrough is a function to stop passing a signal through to the user. Closing the wrapper's channel makes
+// the goroutine forwarding the signal exit. Caller must hold sigPassThroughMapMutex.
+func stopSignalPassThrough(entry sigPassThroughMapEntry) {
+	signal.Stop(entry.sigChan)
+	close(entry.sigChan)
+}
+
 // shutdownSignalGoroutines is a function to stop the signal handling goroutines used to tell the YDB engine what signals
 // have occurred. No signals are recognized by the Go wrapper or YottaDB once this is done. All signal handling reverts to
 // Go standard handling.
@@ -176,6 +268,13 @@ func shutdownSignalGoroutines() {
 	for i := 0; signalsHandled > i; i++ {
 		close(ydbShutdownChannel[i]) // Will wakeup signal goroutine and make it exit
 	}
+	// Stop passing signals through to the user too
+	sigPassThroughMapMutex.Lock()
+	for sig, entry := range sigPassThroughMap {
+		stopSignalPassThrough(entry)
+		delete(sigPassThroughMap, sig)
+	}
+	sigPassThroughMapMutex.Unlock()
 	// Wait for the signal goroutines to exit but with a timeout
 	done := make(chan struct{})
 	go func() {
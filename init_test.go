@@ -0,0 +1,45 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestRegisterSignalPassThrough(t *testing.T) {
+	sigChan := make(chan os.Signal, 1)
+	sigRTMin2 := syscall.Signal(34 + 2)
+	for _, sig := range []syscall.Signal{syscall.SIGUSR2, sigRTMin2} {
+		err := yottadb.RegisterSignalPassThrough(sig, sigChan)
+		Assertnoerr(err, t)
+		err = syscall.Kill(os.Getpid(), sig)
+		Assertnoerr(err, t)
+		select {
+		case got := <-sigChan:
+			assert.Equal(t, sig, got)
+		case <-time.After(10 * time.Second):
+			t.Errorf("FAIL - signal %v was not passed through", sig)
+		}
+		err = yottadb.UnRegisterSignalPassThrough(sig)
+		Assertnoerr(err, t)
+	}
+	// Signals that YottaDB handles cannot be passed through
+	assert.Panics(t, func() { yottadb.RegisterSignalPassThrough(syscall.SIGUSR1, sigChan) })
+	assert.Panics(t, func() { yottadb.UnRegisterSignalPassThrough(syscall.SIGINT) })
+}
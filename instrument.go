@@ -0,0 +1,216 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// Database operations instrumented by beginOp(), which may be timed by EnableStats(), traced by TraceOps(), failed by
+// SetFaultHook(), sampled by EnableSampling() and, for sets, checked by SetValidator()
+const (
+	opData int = iota
+	opDelete
+	opGet
+	opIncr
+	opNodeNext
+	opNodePrev
+	opSet
+	opSubNext
+	opSubPrev
+	opCount
+)
+
+// statNone is the type of statistics of operations that are not timed by EnableStats()
+const statNone int = -1
+
+// opNames are the names of the instrumented operations, as passed to trace filters and fault hooks
+var opNames = [opCount]string{"Data", "Delete", "Get", "Incr", "NodeNext", "NodePrev", "Set", "SubNext", "SubPrev"}
+
+// opStats are the types of statistics recording the durations of the instrumented operations
+var opStats = [opCount]int{statNone, statKill, statGet, statNone, statNext, statNext, statSet, statNext, statNext}
+
+// Instrumentation enabled in hooksEnabled
+const (
+	hookStats    uint32 = 1 << iota // Statistics enabled by EnableStats()
+	hookTrace                       // Tracing enabled by TraceOps()
+	hookFault                       // Fault hook set by SetFaultHook()
+	hookSample                      // Sampling enabled by EnableSampling()
+	hookValidate                    // Validators set by SetValidator()
+)
+
+var hooksEnabled uint32 // Atomic: Instrumentation enabled, so that operations check a single flag when there is none
+
+// setHook is a function to enable or disable one of the instrumentation flags in hooksEnabled
+func setHook(hook uint32, enable bool) {
+	for {
+		old := atomic.LoadUint32(&hooksEnabled)
+		hooks := old &^ hook
+		if enable {
+			hooks |= hook
+		}
+		if atomic.CompareAndSwapUint32(&hooksEnabled, old, hooks) {
+			return
+		}
+	}
+}
+
+// hookEnabled is a function to return whether one of the instrumentation flags is set in hooksEnabled
+func hookEnabled(hook uint32) bool {
+	return 0 != atomic.LoadUint32(&hooksEnabled)&hook
+}
+
+// opInfo is a structure describing an instrumented operation on a node, given either by a key (for the methods of KeyT) or by
+// a variable name and subscripts (for Easy API functions).
+type opInfo struct {
+	op      int         // Operation (opData etc.)
+	key     *KeyT       // Key of the node, or nil if given by varname and subary
+	varname string      // Name of the variable if key is nil
+	subary  []string    // Subscripts of the node if key is nil
+	buf     interface{} // Buffer of the operation (see beginOp()), or nil
+	node    string      // Name of the node in ZWRITE format, once returned by name()
+}
+
+// beginOp is a method to run the instrumentation enabled before an operation on the key's node. buf is the buffer of the
+// operation: the *BufferT of the value fetched, set or returned by an increment, the *BufferT receiving the subscript of
+// SubNext or SubPrev, the *BufferTArray receiving the subscripts of NodeNext or NodePrev, or nil. It is called by the
+// methods of KeyT just before they call YottaDB, only if hooksEnabled is not 0. If a validator or the fault hook returns an
+// error, the method must return that error without calling YottaDB; otherwise it must call (usually defer) the returned
+// function once YottaDB returns.
+func (key *KeyT) beginOp(tptoken uint64, op int, buf interface{}) (func(), error) {
+	return beginOp(tptoken, opInfo{op: op, key: key, buf: buf})
+}
+
+// beginNodeOp is a function to run the instrumentation enabled before an operation on varname(subary...) as beginOp() does
+// for a key. It is called by Easy API functions that do not call a method of KeyT.
+func beginNodeOp(tptoken uint64, op int, varname string, subary []string, buf interface{}) (func(), error) {
+	return beginOp(tptoken, opInfo{op: op, varname: varname, subary: subary, buf: buf})
+}
+
+// endNothing is the function returned by beginOp() when there is nothing to record once YottaDB returns
+func endNothing() {}
+
+// beginOp is a function to run the instrumentation enabled before the operation described by op: validators, then the
+// fault hook and sampling. It returns the function recording the duration of the operation in the statistics and the trace.
+// When only validators are enabled, as in production, and they do not fail, nothing is allocated beyond what the
+// validators need.
+func beginOp(tptoken uint64, op opInfo) (func(), error) {
+	hooks := atomic.LoadUint32(&hooksEnabled)
+	if 0 != hooks&hookValidate && opSet == op.op {
+		if err := validateSet(op.variable(), op.name, op.valueString); nil != err {
+			return nil, err
+		}
+	}
+	if 0 == hooks&^hookValidate {
+		return endNothing, nil
+	}
+	info := &opInfo{}
+	*info = op
+	start := time.Now()
+	end := func() {
+		elapsed := time.Since(start)
+		if 0 != hooks&hookStats && statNone != opStats[info.op] {
+			recordDuration(opStats[info.op], elapsed)
+		}
+		if 0 != hooks&hookTrace {
+			writeTrace(opNames[info.op], info.name(), elapsed, info.value())
+		}
+		runtime.KeepAlive(info.key)
+	}
+	if 0 != hooks&hookFault {
+		if err := injectFault(tptoken, opNames[info.op], info.name()); nil != err {
+			info.setFaultOutput(ErrorCode(err))
+			end()
+			return nil, err
+		}
+	}
+	if 0 != hooks&hookSample {
+		if nil != info.key {
+			info.key.sampleAccess()
+		} else {
+			sampleNode(info.varname, info.subary)
+		}
+	}
+	return end, nil
+}
+
+// variable is a method to return the name of the variable of the node of the operation
+func (info *opInfo) variable() string {
+	if nil != info.key {
+		return string(bufferBytes(info.key.Varnm.getCPtr()))
+	}
+	return info.varname
+}
+
+// name is a method to return the name of the node of the operation in ZWRITE format, formatting it on first use
+func (info *opInfo) name() string {
+	if "" == info.node {
+		if nil != info.key {
+			info.node = info.key.zwriteName()
+		} else {
+			info.node = ZwriteName(info.varname, info.subary)
+		}
+	}
+	return info.node
+}
+
+// value is a method to return the buffer of the value fetched, set or returned by an increment, or nil if the operation
+// has none
+func (info *opInfo) value() *BufferT {
+	if value, ok := info.buf.(*BufferT); ok && opSubNext != info.op && opSubPrev != info.op {
+		return value
+	}
+	return nil
+}
+
+// valueString is a method to return the value of the operation as a string, or "" if it has none
+func (info *opInfo) valueString() string {
+	value := info.value()
+	if nil == value {
+		return ""
+	}
+	str := string(bufferBytes(value.getCPtr()))
+	runtime.KeepAlive(value)
+	return str
+}
+
+// setFaultOutput is a method to set the buffer receiving the result of the operation as YottaDB does when it returns an
+// INVSTRLEN or INSUFFSUBS error with the given code, when the error was injected by the fault hook. Callers that reallocate
+// the buffer with the size reported and try again, such as ValE(), then see a larger size rather than the size they had.
+func (info *opInfo) setFaultOutput(code int) {
+	switch buf := info.buf.(type) {
+	case *BufferT:
+		cbuft := buf.getCPtr()
+		if int(YDB_ERR_INVSTRLEN) == code && opSet != info.op && nil != cbuft {
+			cbuft.len_used = cbuft.len_alloc + 1
+		}
+		runtime.KeepAlive(buf)
+	case *BufferTArray:
+		cbuft := buf.getCPtr()
+		if nil == cbuft {
+			return
+		}
+		switch code {
+		case int(YDB_ERR_INSUFFSUBS):
+			buf.cbuftary.elemUsed = buf.cbuftary.elemAlloc + 1
+		case int(YDB_ERR_INVSTRLEN):
+			if 0 < buf.cbuftary.elemAlloc { // Report the first subscript as too long
+				buf.cbuftary.elemUsed = 0
+				cbuft.len_used = cbuft.len_alloc + 1
+			}
+		}
+		runtime.KeepAlive(buf)
+	}
+}
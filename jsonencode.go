@@ -0,0 +1,170 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONOptions are the options of EncodeJSONE()
+type JSONOptions struct {
+	Arrays   bool   // Write nodes whose child subscripts are exactly 0, 1, 2... as JSON arrays rather than objects
+	Numbers  bool   // Write values that are canonical numbers as JSON numbers rather than strings
+	ValueKey string // Name of the member holding the value of a node that also has descendants, or "" to omit those values
+}
+
+// jsonEncoder holds the state of EncodeJSONE() as it walks a tree
+type jsonEncoder struct {
+	tptoken uint64
+	errstr  *BufferT
+	out     *bufio.Writer
+	varname string
+	opts    *JSONOptions
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API function to write a tree as JSON
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EncodeJSONE is an Easy API function to write the tree rooted at varname(subary...) to writer as a JSON document, with the
+// options given in opts (which may be nil for defaults). Each node with descendants is written as a JSON object with a
+// member for each child subscript in collation order, and each node with only a value is written as that value, a JSON
+// string unless opts.Numbers is set and the value is a canonical number. If the root has neither a value nor descendants,
+// EncodeJSONE() writes null.
+//
+// With opts.Arrays set, a node whose child subscripts are 0, 1, 2 and so on without gaps is written as a JSON array, the
+// inverse of the zero-based indexes used by JSONPathSubscripts(). A node that has both a value and descendants cannot be
+// represented in JSON: its value is written as a member named opts.ValueKey of its object, or omitted if that is empty.
+//
+// The tree is walked a node at a time and written through a buffer, so trees of any size can be written without being held
+// in memory. Detecting arrays walks the children of a node twice. As with DumpTreeE(), the output is not a consistent
+// snapshot if other processes update the tree meanwhile, unless EncodeJSONE() is called within a transaction. If a
+// database call returns an error, the output stops and the function returns the error, leaving incomplete JSON written.
+func EncodeJSONE(tptoken uint64, errstr *BufferT, writer io.Writer, varname string, subary []string, opts *JSONOptions) error {
+	printEntry("EncodeJSONE()")
+	if nil == opts {
+		opts = &JSONOptions{}
+	}
+	enc := &jsonEncoder{tptoken, errstr, bufio.NewWriter(writer), varname, opts}
+	err := enc.encodeNode(append([]string{}, subary...))
+	if flushErr := enc.out.Flush(); nil == err {
+		err = flushErr
+	}
+	return err
+}
+
+// encodeNode is a method to write the tree rooted at the node with the given subscripts as JSON
+func (enc *jsonEncoder) encodeNode(subs []string) error {
+	var isArray bool
+
+	data, err := DataE(enc.tptoken, enc.errstr, enc.varname, subs)
+	if nil != err {
+		return err
+	}
+	switch data {
+	case 0:
+		enc.out.WriteString("null")
+		return nil
+	case 1:
+		return enc.encodeValue(subs)
+	}
+	withValue := 11 == data && "" != enc.opts.ValueKey
+	if enc.opts.Arrays && !withValue {
+		if isArray, err = enc.isArray(subs); nil != err {
+			return err
+		}
+	}
+	enc.out.WriteString(selectString(isArray, "[", "{"))
+	if withValue {
+		enc.encodeString(enc.opts.ValueKey)
+		enc.out.WriteByte(':')
+		if err = enc.encodeValue(subs); nil != err {
+			return err
+		}
+	}
+	child := append(subs[:len(subs):len(subs)], "")
+	for first := !withValue; ; first = false {
+		next, err := SubNextE(enc.tptoken, enc.errstr, enc.varname, child)
+		if nil != err {
+			if YDB_ERR_NODEEND != ErrorCode(err) {
+				return err
+			}
+			break
+		}
+		child[len(child)-1] = next
+		if !first {
+			enc.out.WriteByte(',')
+		}
+		if !isArray {
+			enc.encodeString(next)
+			enc.out.WriteByte(':')
+		}
+		if err = enc.encodeNode(child); nil != err {
+			return err
+		}
+	}
+	enc.out.WriteString(selectString(isArray, "]", "}"))
+	return nil
+}
+
+// isArray is a method to return whether the child subscripts of the node with the given subscripts are 0, 1, 2... in turn
+func (enc *jsonEncoder) isArray(subs []string) (bool, error) {
+	child := append(subs[:len(subs):len(subs)], "")
+	for i := 0; ; i++ {
+		next, err := SubNextE(enc.tptoken, enc.errstr, enc.varname, child)
+		if nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				return 0 < i, nil
+			}
+			return false, err
+		}
+		if strconv.Itoa(i) != next {
+			return false, nil
+		}
+		child[len(child)-1] = next
+	}
+}
+
+// encodeValue is a method to write the value of the node with the given subscripts as a JSON string or number
+func (enc *jsonEncoder) encodeValue(subs []string) error {
+	value, err := ValE(enc.tptoken, enc.errstr, enc.varname, subs)
+	if nil != err {
+		return err
+	}
+	if !enc.opts.Numbers || !IsCanonicalNumber(value) {
+		enc.encodeString(value)
+		return nil
+	}
+	// JSON requires a digit before the decimal point, which canonical numbers omit
+	if strings.HasPrefix(value, "-.") {
+		enc.out.WriteString("-0")
+		value = value[1:]
+	} else if strings.HasPrefix(value, ".") {
+		enc.out.WriteByte('0')
+	}
+	enc.out.WriteString(value)
+	return nil
+}
+
+// encodeString is a method to write a string as a JSON string. Bytes that are not valid UTF-8 are replaced by U+FFFD as
+// they are by json.Marshal().
+func (enc *jsonEncoder) encodeString(s string) {
+	quoted, _ := json.Marshal(s) // Marshaling a string cannot fail
+	enc.out.Write(quoted)
+}
@@ -0,0 +1,61 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestEncodeJSONE(t *testing.T) {
+	var out bytes.Buffer
+
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^encjson", []string{})
+	tree := yottadb.Tree{
+		"name":   "widget \"x\"",
+		"price":  ".5",
+		"tags":   yottadb.Tree{"0": "a", "1": "b", "2": "c"},
+		"sparse": yottadb.Tree{"0": "a", "2": "c"},
+		"dims":   yottadb.Tree{"w": 10, "h": -0.25},
+	}
+	Assertnoerr(yottadb.SetTreeE(yottadb.NOTTP, nil, tree, "^encjson", []string{"doc"}), t)
+
+	Assertnoerr(yottadb.EncodeJSONE(yottadb.NOTTP, nil, &out, "^encjson", []string{"doc"}, nil), t)
+	assert.Equal(t, `{"dims":{"h":"-.25","w":"10"},"name":"widget \"x\"","price":".5","sparse":{"0":"a","2":"c"},`+
+		`"tags":{"0":"a","1":"b","2":"c"}}`, out.String())
+
+	out.Reset()
+	opts := yottadb.JSONOptions{Arrays: true, Numbers: true}
+	Assertnoerr(yottadb.EncodeJSONE(yottadb.NOTTP, nil, &out, "^encjson", []string{"doc"}, &opts), t)
+	assert.Equal(t, `{"dims":{"h":-0.25,"w":10},"name":"widget \"x\"","price":0.5,"sparse":{"0":"a","2":"c"},`+
+		`"tags":["a","b","c"]}`, out.String())
+	assert.True(t, json.Valid(out.Bytes()))
+
+	// The value of a node with descendants is only written with ValueKey, and a missing root is null
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "top", "^encjson", []string{"doc", "tags"}), t)
+	out.Reset()
+	opts.ValueKey = "_value"
+	Assertnoerr(yottadb.EncodeJSONE(yottadb.NOTTP, nil, &out, "^encjson", []string{"doc", "tags"}, &opts), t)
+	assert.Equal(t, `{"_value":"top","0":"a","1":"b","2":"c"}`, out.String())
+	out.Reset()
+	opts.ValueKey = ""
+	Assertnoerr(yottadb.EncodeJSONE(yottadb.NOTTP, nil, &out, "^encjson", []string{"doc", "tags"}, &opts), t)
+	assert.Equal(t, `["a","b","c"]`, out.String())
+	out.Reset()
+	Assertnoerr(yottadb.EncodeJSONE(yottadb.NOTTP, nil, &out, "^encjson", []string{"missing"}, &opts), t)
+	assert.Equal(t, `null`, out.String())
+}
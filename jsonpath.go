@@ -0,0 +1,129 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"strings"
+)
+
+// JSONPathSubscripts is a function to return the subscripts a JSON path addresses below the node holding a JSON document,
+// mapping each member name and each array index of the path to one subscript level. For example, "$.items[2].price"
+// addresses the subscripts "items", "2", "price" so, for a document stored in ^doc(1), the node ^doc(1,"items",2,"price").
+// Array indexes are used as-is, so documents are expected to be stored with the zero-based indexes of JSON.
+//
+// The path must start with "$" (which alone addresses the node holding the document) followed by any number of
+// .name, ['name'], ["name"] and [index] elements. Within brackets, a backslash escapes the quote character or a backslash.
+// Wildcards, recursive descent, slices and filters are not supported as they address more than one node. If the path is
+// not valid, the INVJSONPATH error is returned.
+func JSONPathSubscripts(path string) ([]string, error) {
+	subary := []string{}
+	if !strings.HasPrefix(path, "$") {
+		return nil, newInvJSONPathError(path)
+	}
+	rest := path[1:]
+	for 0 < len(rest) {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if 0 == end {
+				end = len(rest)
+			}
+			name := rest[1:end]
+			if "" == name || "*" == name {
+				return nil, newInvJSONPathError(path)
+			}
+			subary = append(subary, name)
+			rest = rest[end:]
+		case '[':
+			sub, length, ok := parseJSONPathBracket(rest)
+			if !ok {
+				return nil, newInvJSONPathError(path)
+			}
+			subary = append(subary, sub)
+			rest = rest[length:]
+		default:
+			return nil, newInvJSONPathError(path)
+		}
+	}
+	return subary, nil
+}
+
+// JSONPathValE is an Easy API function to return the value at the given JSON path (see JSONPathSubscripts()) of the JSON
+// document stored under varname(subary...), as ValE() returns the value of a node.
+func JSONPathValE(tptoken uint64, errstr *BufferT, varname string, subary []string, path string) (string, error) {
+	printEntry("JSONPathValE()")
+	pathsubs, err := JSONPathSubscripts(path)
+	if nil != err {
+		return "", err
+	}
+	return ValE(tptoken, errstr, varname, append(append([]string{}, subary...), pathsubs...))
+}
+
+// SetJSONPathE is an Easy API function to set the value at the given JSON path (see JSONPathSubscripts()) of the JSON
+// document stored under varname(subary...), as SetValE() sets the value of a node.
+func SetJSONPathE(tptoken uint64, errstr *BufferT, value, varname string, subary []string, path string) error {
+	printEntry("SetJSONPathE()")
+	pathsubs, err := JSONPathSubscripts(path)
+	if nil != err {
+		return err
+	}
+	return SetValE(tptoken, errstr, value, varname, append(append([]string{}, subary...), pathsubs...))
+}
+
+// parseJSONPathBracket is a function to parse the bracketed element at the start of the given path and return its
+// subscript and length, or false if it is not valid.
+func parseJSONPathBracket(path string) (string, int, bool) {
+	var sub strings.Builder
+
+	if 2 > len(path) {
+		return "", 0, false
+	}
+	quote := path[1]
+	if '\'' != quote && '"' != quote {
+		// An array index - digits only, without leading zeros so the subscript is a canonical number
+		end := strings.IndexByte(path, ']')
+		if 1 >= end || (2 < end && '0' == path[1]) {
+			return "", 0, false
+		}
+		for i := 1; i < end; i++ {
+			if '0' > path[i] || '9' < path[i] {
+				return "", 0, false
+			}
+		}
+		return path[1:end], end + 1, true
+	}
+	for i := 2; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++
+			if i == len(path) || (quote != path[i] && '\\' != path[i]) {
+				return "", 0, false
+			}
+			sub.WriteByte(path[i])
+		case quote:
+			if i+1 == len(path) || ']' != path[i+1] {
+				return "", 0, false
+			}
+			return sub.String(), i + 2, true
+		default:
+			sub.WriteByte(path[i])
+		}
+	}
+	return "", 0, false
+}
+
+// newInvJSONPathError is a function to return the INVJSONPATH error for the given path.
+func newInvJSONPathError(path string) error {
+	errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVJSONPATH), "!AD", path, 1)
+	return &YDBError{errcode: (int)(YDB_ERR_INVJSONPATH), errmsg: errmsg}
+}
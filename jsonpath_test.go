@@ -0,0 +1,56 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestJSONPathSubscripts(t *testing.T) {
+	subary, err := yottadb.JSONPathSubscripts("$.items[2].price")
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"items", "2", "price"}, subary)
+	subary, err = yottadb.JSONPathSubscripts(`$['a.b']["say \"hi\""][0]`)
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"a.b", `say "hi"`, "0"}, subary)
+	subary, err = yottadb.JSONPathSubscripts("$")
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{}, subary)
+	for _, path := range []string{"items", "$.", "$.*", "$[*]", "$..a", "$[02]", "$[1", "$['x", "$['x']y"} {
+		_, err = yottadb.JSONPathSubscripts(path)
+		assert.Equal(t, yottadb.YDB_ERR_INVJSONPATH, yottadb.ErrorCode(err), path)
+	}
+}
+
+func TestJSONPathValEAndSetJSONPathE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^jsonpath", []string{})
+	Assertnoerr(yottadb.SetJSONPathE(yottadb.NOTTP, &errstr, "9.99", "^jsonpath", []string{"order", "1"},
+		"$.items[2].price"), t)
+	val, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^jsonpath", []string{"order", "1", "items", "2", "price"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "9.99", val)
+	val, err = yottadb.JSONPathValE(yottadb.NOTTP, &errstr, "^jsonpath", []string{"order", "1"}, "$['items'][2]['price']")
+	Assertnoerr(err, t)
+	assert.Equal(t, "9.99", val)
+	_, err = yottadb.JSONPathValE(yottadb.NOTTP, &errstr, "^jsonpath", []string{"order", "1"}, "$.items[3].price")
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	err = yottadb.SetJSONPathE(yottadb.NOTTP, &errstr, "x", "^jsonpath", []string{}, "$.items[*]")
+	assert.Equal(t, yottadb.YDB_ERR_INVJSONPATH, yottadb.ErrorCode(err))
+}
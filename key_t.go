@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -13,9 +13,12 @@
 package yottadb
 
 import (
+	"bytes"
+	"encoding/binary"
 	"io"
 	"os"
 	"runtime"
+	"strings"
 	"sync/atomic"
 	"unsafe"
 )
@@ -93,6 +96,170 @@ func (key *KeyT) Free() {
 	}
 }
 
+// Equal is a method to return whether the key refers to the same node as other - the same variable name and the same
+// subscripts. The buffers are compared in place, without copying them to Go strings.
+func (key *KeyT) Equal(other *KeyT) bool {
+	printEntry("KeyT.Equal()")
+	if nil == key || nil == other {
+		panic("YDB: *KeyT receiver and parameter of Equal() cannot be nil")
+	}
+	return key.subscriptCount() == other.subscriptCount() && key.HasPrefix(other)
+}
+
+// HasPrefix is a method to return whether the key refers to prefix's node or one of its descendants - the same variable
+// name, with prefix's subscripts as its first subscripts. The buffers are compared in place, without copying them to Go
+// strings.
+func (key *KeyT) HasPrefix(prefix *KeyT) bool {
+	printEntry("KeyT.HasPrefix()")
+	if nil == key || nil == prefix {
+		panic("YDB: *KeyT receiver and parameter of HasPrefix() cannot be nil")
+	}
+	nsubs := prefix.subscriptCount()
+	if nsubs > key.subscriptCount() || !bytes.Equal(bufferBytes(key.Varnm.getCPtr()), bufferBytes(prefix.Varnm.getCPtr())) {
+		return false
+	}
+	for i := uint32(0); i < nsubs; i++ {
+		if !bytes.Equal(key.subscriptBytes(i), prefix.subscriptBytes(i)) {
+			return false
+		}
+	}
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(prefix)
+	return true
+}
+
+// Compare is a method to compare the nodes of two keys in the order NodeNextST() visits them. It returns -1 if the key's
+// node comes before other's, 0 if they are the same node and +1 if it comes after. Keys are ordered by variable name (in
+// byte order) and then by their subscripts compared with CompareSubscripts(), with a node coming before its descendants.
+// Subscripts are compared in place; only the first differing pair of subscripts is copied to Go strings.
+func (key *KeyT) Compare(other *KeyT) int {
+	printEntry("KeyT.Compare()")
+	if nil == key || nil == other {
+		panic("YDB: *KeyT receiver and parameter of Compare() cannot be nil")
+	}
+	if retval := bytes.Compare(bufferBytes(key.Varnm.getCPtr()), bufferBytes(other.Varnm.getCPtr())); 0 != retval {
+		return retval
+	}
+	keysubs := key.subscriptCount()
+	othersubs := other.subscriptCount()
+	for i := uint32(0); i < keysubs && i < othersubs; i++ {
+		keysub := key.subscriptBytes(i)
+		othersub := other.subscriptBytes(i)
+		if !bytes.Equal(keysub, othersub) {
+			return CompareSubscripts(string(keysub), string(othersub))
+		}
+	}
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(other)
+	switch {
+	case keysubs < othersubs:
+		return -1
+	case keysubs > othersubs:
+		return 1
+	}
+	return 0
+}
+
+// Depth is a method to return the number of subscripts in use in the key - 0 for an unsubscripted variable.
+func (key *KeyT) Depth() int {
+	printEntry("KeyT.Depth()")
+	if nil == key {
+		panic("YDB: *KeyT receiver of Depth() cannot be nil")
+	}
+	return int(key.subscriptCount())
+}
+
+// Parent is a method to return a new KeyT for the parent of the key's node - the same variable name and subscripts less
+// the last one - or nil if the key has no subscripts. The new KeyT is allocated with the same sizes as the key and the
+// buffers copied directly, without passing through Go strings. It does not share buffers with the key, so either may be
+// changed or freed without affecting the other, and it should be freed with Free() when no longer needed.
+func (key *KeyT) Parent() *KeyT {
+	var parent KeyT
+
+	printEntry("KeyT.Parent()")
+	if nil == key {
+		panic("YDB: *KeyT receiver of Parent() cannot be nil")
+	}
+	nsubs := key.subscriptCount()
+	if 0 == nsubs {
+		return nil
+	}
+	cvarnm := key.Varnm.getCPtr()
+	if nil == cvarnm {
+		panic("YDB: KeyT receiver of Parent() has subscripts but no variable name allocated")
+	}
+	parent.Alloc(uint32(cvarnm.len_alloc), key.Subary.ElemAlloc(), key.Subary.ElemLenAlloc())
+	copyBuffer(parent.Varnm.getCPtr(), bufferBytes(cvarnm))
+	cbuftary := parent.Subary.getCPtr()
+	for i := uint32(0); i < nsubs-1; i++ {
+		elemptr := (*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*i)))
+		copyBuffer(elemptr, key.subscriptBytes(i))
+	}
+	parent.Subary.cbuftary.elemUsed = nsubs - 1
+	runtime.KeepAlive(key)
+	return &parent
+}
+
+// Key is a method to return a string identifying the key's node, for use as a key of Go maps and caches: the Key() of two
+// KeyTs is the same exactly when Equal() reports they refer to the same node. The string is built from the buffers with a
+// single allocation. It is not meant to be displayed or decoded - it is the variable name followed by each subscript
+// prefixed with its length as a varint.
+func (key *KeyT) Key() string {
+	var lenbuf [binary.MaxVarintLen64]byte
+
+	printEntry("KeyT.Key()")
+	if nil == key {
+		panic("YDB: *KeyT receiver of Key() cannot be nil")
+	}
+	nsubs := key.subscriptCount()
+	varnm := bufferBytes(key.Varnm.getCPtr())
+	size := len(varnm)
+	for i := uint32(0); i < nsubs; i++ {
+		sub := key.subscriptBytes(i)
+		size += binary.PutUvarint(lenbuf[:], uint64(len(sub))) + len(sub)
+	}
+	var ident strings.Builder
+	ident.Grow(size)
+	ident.Write(varnm)
+	for i := uint32(0); i < nsubs; i++ {
+		sub := key.subscriptBytes(i)
+		ident.Write(lenbuf[:binary.PutUvarint(lenbuf[:], uint64(len(sub)))])
+		ident.Write(sub)
+	}
+	runtime.KeepAlive(key)
+	return ident.String()
+}
+
+// Hash64 is a method to return a 64-bit FNV-1a hash of the key's node, computed from the buffers without allocating. Keys
+// that are Equal() have the same hash, so it is suitable for sharding and for hash tables keyed by node.
+func (key *KeyT) Hash64() uint64 {
+	const fnvOffset64 uint64 = 14695981039346656037
+	const fnvPrime64 uint64 = 1099511628211
+
+	printEntry("KeyT.Hash64()")
+	if nil == key {
+		panic("YDB: *KeyT receiver of Hash64() cannot be nil")
+	}
+	hash := fnvOffset64
+	addBytes := func(value []byte) {
+		for _, b := range value {
+			hash ^= uint64(b)
+			hash *= fnvPrime64
+		}
+	}
+	addBytes(bufferBytes(key.Varnm.getCPtr()))
+	nsubs := key.subscriptCount()
+	for i := uint32(0); i < nsubs; i++ {
+		sub := key.subscriptBytes(i)
+		// Hash the length of each subscript so ^x("ab") and ^x("a","b") hash differently
+		length := uint32(len(sub))
+		addBytes([]byte{byte(length >> 24), byte(length >> 16), byte(length >> 8), byte(length)})
+		addBytes(sub)
+	}
+	runtime.KeepAlive(key)
+	return hash
+}
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 //
 // Simple (Threaded) API methods for KeyT
@@ -114,6 +281,9 @@ func (key *KeyT) DataST(tptoken uint64, errstr *BufferT) (uint32, error) {
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return 0, err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -126,9 +296,16 @@ func (key *KeyT) DataST(tptoken uint64, errstr *BufferT) (uint32, error) {
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opData, nil)
+		if nil != err {
+			return 0, err
+		}
+		defer end()
+	}
 	rc := C.ydb_data_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary, &retval)
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.DataST"))
 		return uint32(retval), err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -151,6 +328,9 @@ func (key *KeyT) DeleteST(tptoken uint64, errstr *BufferT, deltype int) error {
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -163,10 +343,17 @@ func (key *KeyT) DeleteST(tptoken uint64, errstr *BufferT, deltype int) error {
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opDelete, nil)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_delete_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary,
 		C.int(deltype))
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.DeleteST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -194,6 +381,9 @@ func (key *KeyT) ValST(tptoken uint64, errstr *BufferT, retval *BufferT) error {
 		// Run initialization but only if we haven't run it before AND we aren't already in initializeYottaDB()
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -206,10 +396,17 @@ func (key *KeyT) ValST(tptoken uint64, errstr *BufferT, retval *BufferT) error {
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opGet, retval)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_get_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary,
 		retval.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.ValST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -218,6 +415,80 @@ func (key *KeyT) ValST(tptoken uint64, errstr *BufferT, retval *BufferT) error {
 	return nil
 }
 
+// ValAppendST is a STAPI method to append the value of the node to buf and return the extended slice, as append() does,
+// for callers that reuse buffers to avoid allocating memory for each fetch.
+//
+// The value is fetched with ValST() into retval, a BufferT the caller allocates once and reuses, then copied into buf with
+// retval.ValAppend(). If the value is longer than retval's allocation, retval is reallocated with the length of the value
+// (so it grows to the longest value fetched) and the value fetched again, up to 16 times if the value keeps growing. With a
+// retval and buf of sufficient size, no memory is allocated. If ValST() returns an error such as GVUNDEF, buf is returned
+// unchanged with the error.
+func (key *KeyT) ValAppendST(tptoken uint64, errstr *BufferT, retval *BufferT, buf []byte) ([]byte, error) {
+	printEntry("KeyT.ValAppendST()")
+	if nil == retval {
+		panic("YDB: *BufferT 'retval' parameter to ValAppendST() cannot be nil")
+	}
+	for reallocs := 0; ; reallocs++ {
+		err := key.ValST(tptoken, errstr, retval)
+		if nil == err {
+			break
+		}
+		if int(YDB_ERR_INVSTRLEN) != ErrorCode(err) || easyAPIMaxReallocs <= reallocs {
+			return buf, err
+		}
+		// Reallocate retval with the size needed
+		size := uint32(retval.getCPtr().len_used)
+		retval.Free()
+		retval.Alloc(size)
+	}
+	return retval.ValAppend(tptoken, errstr, buf)
+}
+
+// ValLenST is a STAPI method to return the length in bytes of the value of the node without copying the value, for size
+// accounting or to allocate a buffer of the right size before calling ValST().
+//
+// ValLenST() calls ydb_get_st() with a buffer of no allocation, so YottaDB returns an INVSTRLEN error with the length of
+// the value in len_used, which ValLenST() returns instead of the error. As the INVSTRLEN is expected, it is not timed by
+// EnableStats(), traced by TraceOps() or passed to the hook of SetFaultHook() as a fetch. Other errors, such as GVUNDEF
+// for a node without a value, are returned with a length of 0.
+func (key *KeyT) ValLenST(tptoken uint64, errstr *BufferT) (uint32, error) {
+	var retval C.ydb_buffer_t
+	var cbuft *C.ydb_buffer_t
+
+	printEntry("KeyT.ValLenST()")
+	if nil == key {
+		panic("YDB: *KeyT receiver of ValLenST() cannot be nil")
+	}
+	if 1 != atomic.LoadUint32(&ydbInitialized) {
+		initializeYottaDB()
+	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return 0, err
+	}
+	if nil != errstr {
+		cbuft = errstr.getCPtr()
+	}
+	vargobuft := key.Varnm.getCPtr()
+	if (nil == vargobuft) || (nil == vargobuft.buf_addr) || (0 == vargobuft.len_used) {
+		panic("YDB: KeyT varname is not allocated, is nil, or has a 0 length")
+	}
+	subgobuftary := key.Subary
+	if nil == subgobuftary {
+		panic("YDB: KeyT Subary is nil")
+	}
+	subbuftary := subgobuftary.getCPtr()
+	rc := C.ydb_get_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary, &retval)
+	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
+	runtime.KeepAlive(errstr)
+	switch rc {
+	case YDB_OK: // Only an empty value fits
+		return 0, nil
+	case YDB_ERR_INVSTRLEN:
+		return uint32(retval.len_used), nil
+	}
+	return 0, key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.ValLenST"))
+}
+
 // IncrST is a STAPI method to increment a given node and return the new value.
 //
 // Matching IncrE(), IncrST() wraps ydb_incr_st() to atomically increment the referenced global or local variable node
@@ -240,6 +511,9 @@ func (key *KeyT) IncrST(tptoken uint64, errstr *BufferT, incr, retval *BufferT)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -252,13 +526,23 @@ func (key *KeyT) IncrST(tptoken uint64, errstr *BufferT, incr, retval *BufferT)
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if hookEnabled(hookValidate) && hasValidator(string(bufferBytes(vargobuft))) {
+		return key.incrValidated(tptoken, errstr, incr, retval)
+	}
 	if nil != incr {
 		incrcbuft = incr.getCPtr()
 	}
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opIncr, retval)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_incr_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary, incrcbuft,
 		retval.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.IncrST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -282,6 +566,9 @@ func (key *KeyT) LockDecrST(tptoken uint64, errstr *BufferT) error {
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -296,7 +583,7 @@ func (key *KeyT) LockDecrST(tptoken uint64, errstr *BufferT) error {
 	subbuftary := subgobuftary.getCPtr()
 	rc := C.ydb_lock_decr_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary)
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.LockDecrST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -323,6 +610,9 @@ func (key *KeyT) LockIncrST(tptoken uint64, errstr *BufferT, timeoutNsec uint64)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -338,7 +628,7 @@ func (key *KeyT) LockIncrST(tptoken uint64, errstr *BufferT, timeoutNsec uint64)
 	rc := C.ydb_lock_incr_st(C.uint64_t(tptoken), cbuft, C.ulonglong(timeoutNsec), vargobuft,
 		C.int(subgobuftary.ElemUsed()), subbuftary)
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.LockIncrST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -373,6 +663,9 @@ func (key *KeyT) NodeNextST(tptoken uint64, errstr *BufferT, next *BufferTArray)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -394,13 +687,20 @@ func (key *KeyT) NodeNextST(tptoken uint64, errstr *BufferT, next *BufferTArray)
 		nextSubaryPtr = nil
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opNodeNext, next)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_node_next_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), subbuftary,
 		(*C.int)(unsafe.Pointer(nextElemPtr)), nextSubaryPtr)
 	if nil != next { // If return area supplied, set the subscript count in the output array (always)
 		next.cbuftary.elemUsed = nextElemUsed
 	}
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.NodeNextST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -436,6 +736,9 @@ func (key *KeyT) NodePrevST(tptoken uint64, errstr *BufferT, prev *BufferTArray)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -457,13 +760,20 @@ func (key *KeyT) NodePrevST(tptoken uint64, errstr *BufferT, prev *BufferTArray)
 		prevSubaryPtr = nil
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opNodePrev, prev)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_node_previous_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()),
 		subbuftary, (*C.int)(unsafe.Pointer(prevElemPtr)), prevSubaryPtr)
 	if nil != prev { // If return area supplied, set the subscript count in the output array (always)
 		prev.cbuftary.elemUsed = prevElemUsed
 	}
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.NodePrevST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -486,6 +796,9 @@ func (key *KeyT) SetValST(tptoken uint64, errstr *BufferT, value *BufferT) error
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -498,9 +811,16 @@ func (key *KeyT) SetValST(tptoken uint64, errstr *BufferT, value *BufferT) error
 		panic("YDB: KeyT Subary is nil")
 	}
 	cbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opSet, value)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_set_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()), cbuftary, value.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.SetValST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -532,6 +852,9 @@ func (key *KeyT) SubNextST(tptoken uint64, errstr *BufferT, retval *BufferT) err
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -544,10 +867,17 @@ func (key *KeyT) SubNextST(tptoken uint64, errstr *BufferT, retval *BufferT) err
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opSubNext, retval)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_subscript_next_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()),
 		subbuftary, retval.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.SubNextST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -577,6 +907,9 @@ func (key *KeyT) SubPrevST(tptoken uint64, errstr *BufferT, retval *BufferT) err
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
@@ -589,10 +922,17 @@ func (key *KeyT) SubPrevST(tptoken uint64, errstr *BufferT, retval *BufferT) err
 		panic("YDB: KeyT Subary is nil")
 	}
 	subbuftary := subgobuftary.getCPtr()
+	if 0 != atomic.LoadUint32(&hooksEnabled) {
+		end, err := key.beginOp(tptoken, opSubPrev, retval)
+		if nil != err {
+			return err
+		}
+		defer end()
+	}
 	rc := C.ydb_subscript_previous_st(C.uint64_t(tptoken), cbuft, vargobuft, C.int(subgobuftary.ElemUsed()),
 		subbuftary, retval.getCPtr())
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := key.withParams(engineError(tptoken, errstr, int(rc), "KeyT.SubPrevST"))
 		return err
 	}
 	runtime.KeepAlive(key) // Make sure key hangs around through the YDB call
@@ -600,3 +940,100 @@ func (key *KeyT) SubPrevST(tptoken uint64, errstr *BufferT, retval *BufferT) err
 	runtime.KeepAlive(errstr)
 	return nil
 }
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// subscriptCount is a method to return the number of subscripts in use in the key (0 if Subary is not allocated).
+func (key *KeyT) subscriptCount() uint32 {
+	if nil == key.Subary {
+		return 0
+	}
+	return key.Subary.ElemUsed()
+}
+
+// subscriptBytes is a method to return the bytes of the subscript of the key with the given index (which must be less
+// than subscriptCount()) without copying them. See bufferBytes() for restrictions on the use of the slice.
+func (key *KeyT) subscriptBytes(idx uint32) []byte {
+	cbuftary := key.Subary.getCPtr()
+	if nil == cbuftary || idx >= key.Subary.ElemAlloc() {
+		return nil
+	}
+	return bufferBytes((*C.ydb_buffer_t)(unsafe.Pointer(uintptr(unsafe.Pointer(cbuftary)) + uintptr(C.sizeof_ydb_buffer_t*idx))))
+}
+
+// zwriteName is a method to return the name of the key's node as output by ZWRITE, e.g. ^x("a",1).
+func (key *KeyT) zwriteName() string {
+	subary := make([]string, key.subscriptCount())
+	for i := range subary {
+		subary[i] = string(key.subscriptBytes(uint32(i)))
+	}
+	name := ZwriteName(string(bufferBytes(key.Varnm.getCPtr())), subary)
+	runtime.KeepAlive(key)
+	return name
+}
+
+// withParams is a method to record the variable name and subscripts of the key as the parameters of the operation that
+// failed with the given error, if it is a YDBError reporting an error (rather than a return code such as NODEEND), and
+// return the error.
+func (key *KeyT) withParams(err error) error {
+	yerr, ok := err.(*YDBError)
+	if !ok || 0 <= yerr.errcode || YDB_ERR_NODEEND == yerr.errcode { // TP and lock return codes are positive
+		return err
+	}
+	nsubs := key.subscriptCount()
+	yerr.params = make([]string, 0, nsubs+1)
+	yerr.params = append(yerr.params, string(bufferBytes(key.Varnm.getCPtr())))
+	for i := uint32(0); i < nsubs; i++ {
+		yerr.params = append(yerr.params, string(key.subscriptBytes(i)))
+	}
+	runtime.KeepAlive(key)
+	return err
+}
+
+// incrValidated is a method to increment the key's node as IncrClampE() does, so that the validators of its variable are
+// called with the incremented value, and return the value through retval as IncrST() does
+func (key *KeyT) incrValidated(tptoken uint64, errstr, incr, retval *BufferT) error {
+	var incrstr string
+
+	subary := make([]string, key.subscriptCount())
+	for i := range subary {
+		subary[i] = string(key.subscriptBytes(uint32(i)))
+	}
+	if nil != incr {
+		incrstr = string(bufferBytes(incr.getCPtr()))
+	}
+	value, _, err := IncrClampE(tptoken, errstr, incrstr, "", "", string(bufferBytes(key.Varnm.getCPtr())), subary)
+	runtime.KeepAlive(key)
+	runtime.KeepAlive(incr)
+	if nil != err {
+		return key.withParams(err)
+	}
+	return retval.SetValStr(tptoken, errstr, value)
+}
+
+// copyBuffer is a function to copy the given bytes into the given C buffer and set its len_used. The buffer must have been
+// allocated at least as long as the bytes.
+func copyBuffer(cbuft *C.ydb_buffer_t, value []byte) {
+	if 0 < len(value) {
+		copy(unsafe.Slice((*byte)(unsafe.Pointer(cbuft.buf_addr)), len(value)), value)
+	}
+	cbuft.len_used = C.uint(len(value))
+}
+
+// bufferBytes is a function to return the bytes in use in the given C buffer (nil if there is no buffer) without copying
+// them. The slice refers to C memory, so must not be kept or modified, and the owner of the buffer must be kept alive (e.g.
+// with runtime.KeepAlive()) while the slice is in use.
+func bufferBytes(cbuft *C.ydb_buffer_t) []byte {
+	if nil == cbuft || nil == cbuft.buf_addr {
+		return nil
+	}
+	length := cbuft.len_used
+	if length > cbuft.len_alloc { // After INVSTRLEN, only len_alloc bytes are valid
+		length = cbuft.len_alloc
+	}
+	return unsafe.Slice((*byte)(unsafe.Pointer(cbuft.buf_addr)), int(length))
+}
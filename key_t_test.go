@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -1461,16 +1461,24 @@ func TestKeyTSetWithDifferentErrors(t *testing.T) {
 }
 
 func TestKeyTSimpleAPITPDeadlock(t *testing.T) {
-	t.Skipf("This test causes a deadlock; we do not currently believe this can be avoided")
+	// Using NOTTP instead of the tptoken passed to the closure would deadlock - expect the NESTEDTPTOKEN error instead
 	fn := func(tptoken uint64, errstr *yottadb.BufferT) int32 {
 		err := yottadb.SetValE(yottadb.NOTTP, errstr, "Hello world", "^Hello", []string{})
 		assert.NotNil(t, err)
-		assert.Equal(t, "", err.Error())
-		return 0
+		assert.Equal(t, yottadb.YDB_ERR_NESTEDTPTOKEN, yottadb.ErrorCode(err))
+		_, err = yottadb.MessageT(yottadb.NOTTP, errstr, yottadb.YDB_ERR_GVUNDEF)
+		assert.Equal(t, yottadb.YDB_ERR_NESTEDTPTOKEN, yottadb.ErrorCode(err))
+		// Calls with the transaction's tptoken work as usual
+		err = yottadb.SetValE(tptoken, errstr, "Hello world", "^Hello", []string{})
+		assert.Nil(t, err)
+		return yottadb.YDB_OK
 	}
 	err := yottadb.TpE(yottadb.NOTTP, nil, fn, "BATCH", []string{})
-	assert.NotNil(t, err)
-	assert.Equal(t, "", err.Error())
+	assert.Nil(t, err)
+	// Once the transaction completes, NOTTP is correct again
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^Hello", []string{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Hello world", val)
 }
 
 func TestKeyTWithNil(t *testing.T) {
@@ -1523,3 +1531,126 @@ func TestKeyTWithNil(t *testing.T) {
 		assert.NotEqual(t, 0, total_panics)
 	}
 }
+
+// makeKey is a function to return an allocated KeyT for varname(subs...), freed when the test ends
+func makeKey(t *testing.T, varname string, subs ...string) *yottadb.KeyT {
+	var key yottadb.KeyT
+
+	key.Alloc(32, 8, 32)
+	t.Cleanup(key.Free)
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, nil, varname), t)
+	for i, sub := range subs {
+		Assertnoerr(key.Subary.SetValStr(yottadb.NOTTP, nil, uint32(i), sub), t)
+	}
+	Assertnoerr(key.Subary.SetElemUsed(yottadb.NOTTP, nil, uint32(len(subs))), t)
+	return &key
+}
+
+func TestKeyTEqualHasPrefixCompare(t *testing.T) {
+	key := makeKey(t, "^x", "a", "2")
+	assert.True(t, key.Equal(makeKey(t, "^x", "a", "2")))
+	assert.False(t, key.Equal(makeKey(t, "^x", "a")))
+	assert.False(t, key.Equal(makeKey(t, "^y", "a", "2")))
+	assert.True(t, key.HasPrefix(makeKey(t, "^x", "a")))
+	assert.True(t, key.HasPrefix(makeKey(t, "^x")))
+	assert.True(t, key.HasPrefix(key))
+	assert.False(t, key.HasPrefix(makeKey(t, "^x", "b")))
+	assert.False(t, makeKey(t, "^x", "a").HasPrefix(key))
+	// Subscripts compare in M collation order, and a node comes before its descendants
+	assert.Equal(t, 0, key.Compare(makeKey(t, "^x", "a", "2")))
+	assert.Equal(t, -1, key.Compare(makeKey(t, "^x", "a", "10")))
+	assert.Equal(t, 1, key.Compare(makeKey(t, "^x", "a", "")))
+	assert.Equal(t, 1, key.Compare(makeKey(t, "^x", "a")))
+	assert.Equal(t, -1, key.Compare(makeKey(t, "^x", "a", "2", "x")))
+	assert.Equal(t, -1, key.Compare(makeKey(t, "^y")))
+	assert.Equal(t, 1, key.Compare(makeKey(t, "^x", "1", "2")))
+}
+
+func TestKeyTParentDepth(t *testing.T) {
+	key := makeKey(t, "^x", "a", "2")
+	assert.Equal(t, 2, key.Depth())
+	parent := key.Parent()
+	defer parent.Free()
+	assert.Equal(t, 1, parent.Depth())
+	assert.True(t, parent.Equal(makeKey(t, "^x", "a")))
+	// The parent has its own buffers
+	Assertnoerr(parent.Subary.SetValStr(yottadb.NOTTP, nil, 0, "b"), t)
+	assert.True(t, key.Equal(makeKey(t, "^x", "a", "2")))
+	root := parent.Parent()
+	defer root.Free()
+	assert.Equal(t, 0, root.Depth())
+	assert.True(t, root.Equal(makeKey(t, "^x")))
+	assert.Nil(t, root.Parent())
+}
+
+func TestKeyTKeyHash64(t *testing.T) {
+	key := makeKey(t, "^x", "a", "2")
+	same := makeKey(t, "^x", "a", "2")
+	assert.Equal(t, key.Key(), same.Key())
+	assert.Equal(t, key.Hash64(), same.Hash64())
+	// Keys differ for nodes that would look alike if subscripts were simply concatenated
+	distinct := []*yottadb.KeyT{key, makeKey(t, "^x", "a2"), makeKey(t, "^x", "a", "2", ""), makeKey(t, "^x", "a"),
+		makeKey(t, "^x"), makeKey(t, "^y", "a", "2")}
+	seen := map[string]bool{}
+	hashes := map[uint64]bool{}
+	for _, k := range distinct {
+		seen[k.Key()] = true
+		hashes[k.Hash64()] = true
+	}
+	assert.Equal(t, len(distinct), len(seen))
+	assert.Equal(t, len(distinct), len(hashes))
+}
+
+func TestKeyTValAppendST(t *testing.T) {
+	var retval yottadb.BufferT
+
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "a longer value", "^valappend", []string{"a"}), t)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^valappend", []string{})
+	key := makeKey(t, "^valappend", "a")
+	retval.Alloc(4) // Too small - grown by ValAppendST()
+	defer retval.Free()
+	buf := make([]byte, 0, 64)
+	buf = append(buf, "value: "...)
+	buf, err := key.ValAppendST(yottadb.NOTTP, nil, &retval, buf)
+	Assertnoerr(err, t)
+	assert.Equal(t, "value: a longer value", string(buf))
+	assert.Equal(t, 64, cap(buf)) // Appended in place
+	buf, err = key.ValAppendST(yottadb.NOTTP, nil, &retval, buf[:0])
+	Assertnoerr(err, t)
+	assert.Equal(t, "a longer value", string(buf))
+	missing := makeKey(t, "^valappend", "b")
+	buf, err = missing.ValAppendST(yottadb.NOTTP, nil, &retval, buf)
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	assert.Equal(t, "a longer value", string(buf))
+}
+
+func TestKeyTValLenST(t *testing.T) {
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "a longer value", "^vallen", []string{"a"}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "", "^vallen", []string{"b"}), t)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^vallen", []string{})
+	key := makeKey(t, "^vallen", "a")
+	length, err := key.ValLenST(yottadb.NOTTP, nil)
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(14), length)
+	length, err = yottadb.ValLenE(yottadb.NOTTP, nil, "^vallen", []string{"b"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), length)
+	_, err = yottadb.ValLenE(yottadb.NOTTP, nil, "^vallen", []string{"c"})
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	// The INVSTRLEN error giving the length is not a failed fetch for statistics, tracing or fault hooks
+	var trace bytes.Buffer
+	yottadb.ResetStats()
+	yottadb.EnableStats(true)
+	yottadb.TraceOps(&trace, nil)
+	yottadb.SetFaultHook(func(tptoken uint64, op, node string) error {
+		return yottadb.NewError(tptoken, nil, yottadb.YDB_ERR_GVUNDEF)
+	})
+	length, err = key.ValLenST(yottadb.NOTTP, nil)
+	yottadb.SetFaultHook(nil)
+	yottadb.TraceOps(nil, nil)
+	yottadb.EnableStats(false)
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(14), length)
+	assert.Equal(t, uint64(0), yottadb.Stats()["Get"].Count)
+	assert.Equal(t, "", trace.String())
+}
@@ -0,0 +1,204 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Type tags that start each part of an encoded key. Their values determine how parts of different types collate.
+const (
+	keyTagInt    byte = 0x02 // Signed integer - 8 bytes big-endian with the sign bit flipped
+	keyTagUint   byte = 0x03 // Unsigned integer - 8 bytes big-endian
+	keyTagFloat  byte = 0x04 // Floating point - 8 bytes big-endian IEEE 754, transformed to collate numerically
+	keyTagTime   byte = 0x05 // Time - 8 bytes big-endian nanoseconds since the Unix epoch with the sign bit flipped
+	keyTagString byte = 0x06 // String - bytes with 0x00 escaped as 0x00 0xFF, terminated by 0x00 0x01
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Functions to encode several values as a single subscript
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// EncodeKey is a function to encode several values into a single subscript whose collation order matches that of the
+// values, comparing the first values, then the second values, and so on. This is useful for composite keys with more parts
+// than the maximum number of subscripts (YDB_MAX_SUBS) or for keeping related values together in one subscript.
+//
+// Supported value types are the signed integer types (collating in numeric order), the unsigned integer types (collating
+// in numeric order, after all signed integers), float32 and float64 (collating in numeric order), time.Time (collating in
+// time order, with the time zone not retained) and string and []byte (collating in byte order, with a string that is a
+// prefix of another collating first). Values of different types collate in that order of types. Any other type panics.
+//
+// The encoding is binary, so it is meant for use as a subscript rather than for display. It never looks like a canonical
+// number, so encoded keys always collate as strings, after any numeric subscripts at the same level. Use DecodeKey() to
+// recover the values.
+func EncodeKey(parts ...interface{}) string {
+	var key strings.Builder
+	var num [8]byte
+
+	for _, part := range parts {
+		switch val := part.(type) {
+		case int:
+			encodeKeyInt(&key, &num, int64(val))
+		case int8:
+			encodeKeyInt(&key, &num, int64(val))
+		case int16:
+			encodeKeyInt(&key, &num, int64(val))
+		case int32:
+			encodeKeyInt(&key, &num, int64(val))
+		case int64:
+			encodeKeyInt(&key, &num, val)
+		case uint:
+			encodeKeyUint(&key, &num, keyTagUint, uint64(val))
+		case uint8:
+			encodeKeyUint(&key, &num, keyTagUint, uint64(val))
+		case uint16:
+			encodeKeyUint(&key, &num, keyTagUint, uint64(val))
+		case uint32:
+			encodeKeyUint(&key, &num, keyTagUint, uint64(val))
+		case uint64:
+			encodeKeyUint(&key, &num, keyTagUint, val)
+		case float32:
+			encodeKeyFloat(&key, &num, float64(val))
+		case float64:
+			encodeKeyFloat(&key, &num, val)
+		case time.Time:
+			encodeKeyUint(&key, &num, keyTagTime, uint64(val.UnixNano())^(1<<63))
+		case string:
+			encodeKeyString(&key, val)
+		case []byte:
+			encodeKeyString(&key, string(val))
+		default:
+			panic(fmt.Sprintf("YDB: Unsupported type %T passed to EncodeKey()", part))
+		}
+	}
+	return key.String()
+}
+
+// DecodeKey is a function to decode a subscript created by EncodeKey() back into its values. Signed integers are returned
+// as int64, unsigned integers as uint64, floating point values as float64, times as time.Time in UTC and strings and byte
+// slices as string.
+//
+// If the subscript is not a valid encoding, the INVKEYENC error is returned.
+func DecodeKey(key string) ([]interface{}, error) {
+	var parts []interface{}
+
+	for i := 0; i < len(key); {
+		tag := key[i]
+		i++
+		switch tag {
+		case keyTagInt, keyTagUint, keyTagFloat, keyTagTime:
+			if len(key) < i+8 {
+				return nil, newInvKeyEncError(key)
+			}
+			num := binary.BigEndian.Uint64([]byte(key[i : i+8]))
+			i += 8
+			switch tag {
+			case keyTagInt:
+				parts = append(parts, int64(num^(1<<63)))
+			case keyTagUint:
+				parts = append(parts, num)
+			case keyTagFloat:
+				if 0 != num&(1<<63) {
+					num ^= 1 << 63
+				} else {
+					num = ^num
+				}
+				parts = append(parts, math.Float64frombits(num))
+			case keyTagTime:
+				parts = append(parts, time.Unix(0, int64(num^(1<<63))).UTC())
+			}
+		case keyTagString:
+			var str strings.Builder
+
+			for {
+				end := strings.IndexByte(key[i:], 0x00)
+				if -1 == end || len(key) <= i+end+1 {
+					return nil, newInvKeyEncError(key)
+				}
+				str.WriteString(key[i : i+end])
+				i += end + 2
+				if 0x01 == key[i-1] {
+					break // Terminator
+				}
+				if 0xFF != key[i-1] {
+					return nil, newInvKeyEncError(key)
+				}
+				str.WriteByte(0x00) // Escaped 0x00
+			}
+			parts = append(parts, str.String())
+		default:
+			return nil, newInvKeyEncError(key)
+		}
+	}
+	return parts, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// encodeKeyInt is a function to append the encoding of a signed integer to a key. Flipping the sign bit makes negative
+// numbers collate before positive numbers.
+func encodeKeyInt(key *strings.Builder, num *[8]byte, val int64) {
+	encodeKeyUint(key, num, keyTagInt, uint64(val)^(1<<63))
+}
+
+// encodeKeyUint is a function to append a tag and an 8 byte big-endian number to a key.
+func encodeKeyUint(key *strings.Builder, num *[8]byte, tag byte, val uint64) {
+	key.WriteByte(tag)
+	binary.BigEndian.PutUint64(num[:], val)
+	key.Write(num[:])
+}
+
+// encodeKeyFloat is a function to append the encoding of a floating point number to a key. Positive numbers have their sign
+// bit set, and negative numbers have all their bits flipped, so the encodings collate in numeric order.
+func encodeKeyFloat(key *strings.Builder, num *[8]byte, val float64) {
+	bits := math.Float64bits(val)
+	if 0 != bits&(1<<63) {
+		bits = ^bits
+	} else {
+		bits ^= 1 << 63
+	}
+	encodeKeyUint(key, num, keyTagFloat, bits)
+}
+
+// encodeKeyString is a function to append the encoding of a string to a key. Escaping 0x00 bytes as 0x00 0xFF and
+// terminating the string with 0x00 0x01 keeps a string that is a prefix of another collating before it.
+func encodeKeyString(key *strings.Builder, val string) {
+	key.WriteByte(keyTagString)
+	for {
+		end := strings.IndexByte(val, 0x00)
+		if -1 == end {
+			break
+		}
+		key.WriteString(val[:end])
+		key.WriteString("\x00\xff")
+		val = val[end+1:]
+	}
+	key.WriteString(val)
+	key.WriteString("\x00\x01")
+}
+
+// newInvKeyEncError is a function to create the INVKEYENC error for a subscript that is not a valid EncodeKey() encoding.
+func newInvKeyEncError(key string) error {
+	errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVKEYENC), "!AD", Quote(key), 1)
+	return &YDBError{errcode: (int)(YDB_ERR_INVKEYENC), errmsg: errmsg}
+}
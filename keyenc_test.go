@@ -0,0 +1,85 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestEncodeKeyRoundTrip(t *testing.T) {
+	when := time.Date(2024, 2, 29, 12, 30, 0, 123, time.UTC)
+	key := yottadb.EncodeKey(int8(-5), 42, uint16(7), float32(1.5), -2.25, when, "a\x00b", []byte("xyz"), "")
+	assert.False(t, yottadb.IsCanonicalNumber(key))
+	parts, err := yottadb.DecodeKey(key)
+	Assertnoerr(err, t)
+	assert.Equal(t, []interface{}{int64(-5), int64(42), uint64(7), 1.5, -2.25, when, "a\x00b", "xyz", ""}, parts)
+	// An empty key has no parts
+	parts, err = yottadb.DecodeKey(yottadb.EncodeKey())
+	Assertnoerr(err, t)
+	assert.Empty(t, parts)
+	assert.Panics(t, func() { yottadb.EncodeKey(true) })
+}
+
+func TestDecodeKeyErrors(t *testing.T) {
+	valid := yottadb.EncodeKey(1, "abc")
+	invalid := []string{"x", valid[:5], valid[:len(valid)-1], "\x06abc", "\x06a\x00\x02", "\x06a\x00"}
+	for _, key := range invalid {
+		_, err := yottadb.DecodeKey(key)
+		assert.Equal(t, yottadb.YDB_ERR_INVKEYENC, yottadb.ErrorCode(err), "decoding %q", key)
+	}
+}
+
+func TestEncodeKeyCollation(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	// Keys in the order they must collate
+	keys := []string{
+		yottadb.EncodeKey(math.MinInt64, "z"),
+		yottadb.EncodeKey(-1, "z"),
+		yottadb.EncodeKey(0, ""),
+		yottadb.EncodeKey(0, "a"),
+		yottadb.EncodeKey(0, "a\x00"),
+		yottadb.EncodeKey(0, "ab"),
+		yottadb.EncodeKey(1, "a"),
+		yottadb.EncodeKey(math.MaxInt64),
+		yottadb.EncodeKey(uint(0)),
+		yottadb.EncodeKey(math.Inf(-1)),
+		yottadb.EncodeKey(-1e100),
+		yottadb.EncodeKey(-0.5),
+		yottadb.EncodeKey(0.0),
+		yottadb.EncodeKey(0.5),
+		yottadb.EncodeKey(1e100),
+		yottadb.EncodeKey(time.Unix(-1, 0)),
+		yottadb.EncodeKey(time.Unix(0, 0)),
+		yottadb.EncodeKey(time.Unix(0, 1)),
+		yottadb.EncodeKey("a"),
+	}
+	for i := len(keys) - 1; 0 <= i; i-- { // Set in reverse so the database does the sorting
+		err := yottadb.SetValE(yottadb.NOTTP, &errstr, "", "^keyenc", []string{keys[i]})
+		Assertnoerr(err, t)
+	}
+	sub := ""
+	for i := range keys {
+		next, err := yottadb.SubNextE(yottadb.NOTTP, &errstr, "^keyenc", []string{sub})
+		Assertnoerr(err, t)
+		assert.Equal(t, keys[i], next, "key %d", i)
+		sub = next
+	}
+}
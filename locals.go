@@ -0,0 +1,110 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+// LocalsSnapshot is a structure holding the local variables of the process saved by SaveLocalsE()
+type LocalsSnapshot struct {
+	nodes []localNode // Nodes with values in collation order
+}
+
+// localNode is a structure holding a local variable node saved in a LocalsSnapshot
+type localNode struct {
+	varname string
+	TreeNode
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API functions to save and restore local variables
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SaveLocalsE is an Easy API function to save every local variable of the process, for a later RestoreLocalsE() to discard
+// any changes made to local variables in the meantime, for example by M routines called with CallMT(). Together they have
+// the effect of an M NEW of all local variables around the calls made in between.
+//
+// The snapshot is a copy of the values, so SaveLocalsE() takes time and memory in proportion to the local variables saved.
+//
+// If a database call returns an error, the function returns the error.
+func SaveLocalsE(tptoken uint64, errstr *BufferT) (*LocalsSnapshot, error) {
+	var snapshot LocalsSnapshot
+
+	printEntry("SaveLocalsE()")
+	saveVar := func(varname string) error {
+		data, err := DataE(tptoken, errstr, varname, []string{})
+		if nil != err {
+			return err
+		}
+		subary := []string{}
+		if 1 != data%10 {
+			subary, err = NodeNextE(tptoken, errstr, varname, subary)
+		}
+		for nil == err {
+			var value string
+
+			if value, err = ValE(tptoken, errstr, varname, subary); nil != err {
+				return err
+			}
+			snapshot.nodes = append(snapshot.nodes, localNode{varname, TreeNode{subary, value}})
+			subary, err = NodeNextE(tptoken, errstr, varname, subary)
+		}
+		if YDB_ERR_NODEEND == ErrorCode(err) {
+			return nil
+		}
+		return err
+	}
+	// No local variable name collates before %
+	name := "%"
+	data, err := DataE(tptoken, errstr, name, []string{})
+	if nil != err {
+		return nil, err
+	}
+	if 0 != data {
+		if err = saveVar(name); nil != err {
+			return nil, err
+		}
+	}
+	for {
+		if name, err = SubNextE(tptoken, errstr, name, []string{}); nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				return &snapshot, nil
+			}
+			return nil, err
+		}
+		if err = saveVar(name); nil != err {
+			return nil, err
+		}
+	}
+}
+
+// RestoreLocalsE is an Easy API function to restore the local variables of the process to those saved in snapshot by
+// SaveLocalsE(). Local variables created since are deleted and those changed or deleted since get back their saved values.
+// A snapshot may be restored any number of times.
+//
+// If a database call returns an error, the function returns the error and the local variables are left partly restored.
+func RestoreLocalsE(tptoken uint64, errstr *BufferT, snapshot *LocalsSnapshot) error {
+	printEntry("RestoreLocalsE()")
+	if nil == snapshot {
+		panic("YDB: RestoreLocalsE() called with a nil snapshot")
+	}
+	// Deleting with an empty list of exclusions deletes all local variables
+	if err := DeleteExclE(tptoken, errstr, []string{}); nil != err {
+		return err
+	}
+	for _, node := range snapshot.nodes {
+		if err := SetValE(tptoken, errstr, node.Value, node.varname, node.Subary); nil != err {
+			return err
+		}
+	}
+	return nil
+}
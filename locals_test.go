@@ -0,0 +1,57 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestSaveRestoreLocalsE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	Assertnoerr(yottadb.DeleteExclE(yottadb.NOTTP, &errstr, []string{}), t)
+	defer yottadb.DeleteExclE(yottadb.NOTTP, &errstr, []string{})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "root", "locals", []string{}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "ab", "locals", []string{"a", "b"}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "", "%percent", []string{"1"}), t)
+	snapshot, err := yottadb.SaveLocalsE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	for i := 0; i < 2; i++ {
+		// Change, delete and create local variables, then restore the snapshot
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "changed", "locals", []string{}), t)
+		Assertnoerr(yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "%percent", []string{}), t)
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "new", "localsnew", []string{"x"}), t)
+		Assertnoerr(yottadb.RestoreLocalsE(yottadb.NOTTP, &errstr, snapshot), t)
+		value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "locals", []string{})
+		Assertnoerr(err, t)
+		assert.Equal(t, "root", value)
+		value, err = yottadb.ValE(yottadb.NOTTP, &errstr, "locals", []string{"a", "b"})
+		Assertnoerr(err, t)
+		assert.Equal(t, "ab", value)
+		data, err := yottadb.DataE(yottadb.NOTTP, &errstr, "locals", []string{"a"})
+		Assertnoerr(err, t)
+		assert.Equal(t, uint32(10), data)
+		data, err = yottadb.DataE(yottadb.NOTTP, &errstr, "%percent", []string{})
+		Assertnoerr(err, t)
+		assert.Equal(t, uint32(10), data)
+		data, err = yottadb.DataE(yottadb.NOTTP, &errstr, "localsnew", []string{})
+		Assertnoerr(err, t)
+		assert.Equal(t, uint32(0), data)
+	}
+	assert.Panics(t, func() { yottadb.RestoreLocalsE(yottadb.NOTTP, &errstr, nil) })
+}
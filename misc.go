@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -31,6 +31,44 @@ var wgexit sync.WaitGroup
 var mtxInExit sync.Mutex
 var exitRun bool
 
+// memCategory identifies what C memory allocated by the wrapper is used for, for MemStats()
+type memCategory int
+
+const (
+	memCatBufferT      memCategory = iota // BufferT structures and their buffers (including those of KeyT)
+	memCatBufferTArray                    // BufferTArray structures and their buffers (including those of KeyT)
+	memCatCallMDesc                       // CallMDesc call-in descriptors
+	memCatParms                           // Parameter blocks and strings for the duration of calls into YottaDB
+	memCatMessages                        // Buffers for fetching error messages
+	memCatCount                           // Number of categories
+)
+
+// MemCounts holds counts of C memory allocations made and freed by the wrapper
+type MemCounts struct {
+	Allocs     uint64 // Number of allocations made
+	Frees      uint64 // Number of allocations freed
+	AllocBytes uint64 // Total bytes allocated
+	FreeBytes  uint64 // Total bytes freed
+}
+
+// MemStatsT holds counts of C memory allocations made and freed by the wrapper broken down by what the memory is used for,
+// as returned by MemStats().
+type MemStatsT struct {
+	BufferT      MemCounts // BufferT structures and their buffers, including those in KeyT structures
+	BufferTArray MemCounts // BufferTArray structures and their buffers, including those in KeyT structures
+	CallMDesc    MemCounts // Call-in descriptors used by CallMDesc
+	Parms        MemCounts // Parameter blocks and strings allocated for the duration of calls into YottaDB
+	Messages     MemCounts // Buffers used to fetch error messages
+	Total        MemCounts // Sum of all of the above
+}
+
+var cMemCounts [memCatCount]MemCounts // Counters of the C memory allocated and freed by the wrapper (updated atomically)
+
+var cSweepOnExit uint32 // Set (1) to run SweepCFrees() in Exit() - see SetSweepCFreesOnExit()
+
+// maxSweepPasses is the maximum number of garbage collections SweepCFrees() runs
+const maxSweepPasses int = 10
+
 ////////////////////////////////////////////////////////////////////////////////////////////////////
 //
 // Miscellaneous functions
@@ -91,7 +129,7 @@ func initkey(tptoken uint64, errstr *BufferT, dbkey *KeyT, varname string, subar
 
 // allocMem is a function to allocate memory optionally initializing it in various ways. This can be a future
 // point where storage management sanity code can be added.
-func allocMem(size C.size_t) unsafe.Pointer {
+func allocMem(size C.size_t, category memCategory) unsafe.Pointer {
 	// This initial call must be to calloc() to get initialized (cleared) storage. We cannot allocate it and then
 	// do another call to initialize it as that means uninitialized memory is traversing the cgo boundary which
 	// is what triggers the cgo bug mentioned in the cgo docs (https://golang.org/cmd/cgo/#hdr-Passing_pointers).
@@ -99,15 +137,110 @@ func allocMem(size C.size_t) unsafe.Pointer {
 	if dbgInitMalloc && (0x00 != dbgInitMallocChar) { // Want to initialize to something other than nulls
 		_ = C.memset(mem, dbgInitMallocChar, size)
 	}
+	atomic.AddUint64(&cMemCounts[category].Allocs, 1)
+	atomic.AddUint64(&cMemCounts[category].AllocBytes, uint64(size))
 	return mem
 }
 
-// freeMem is a function to return memory allocated with allocMem() or C.calloc().
-func freeMem(mem unsafe.Pointer, size C.size_t) {
+// allocCString is a function to return a copy of a Go string in C memory as C.CString() does, but counting the allocation
+// like allocMem() does. The returned memory must be released with freeMem() with a size of len(str)+1.
+func allocCString(str string, category memCategory) *C.char {
+	cstr := C.CString(str)
+	atomic.AddUint64(&cMemCounts[category].Allocs, 1)
+	atomic.AddUint64(&cMemCounts[category].AllocBytes, uint64(len(str)+1))
+	return cstr
+}
+
+// freeMem is a function to return memory allocated with allocMem() or allocCString(). The size and category must be those
+// of the allocation so the counts of memory freed are accurate.
+func freeMem(mem unsafe.Pointer, size C.size_t, category memCategory) {
 	if dbgInitFree {
 		_ = C.memset(mem, dbgInitFreeChar, size)
 	}
 	C.free(mem)
+	atomic.AddUint64(&cMemCounts[category].Frees, 1)
+	atomic.AddUint64(&cMemCounts[category].FreeBytes, uint64(size))
+}
+
+// PendingCFrees is a function to return the number of C memory allocations made by the wrapper that have not yet been
+// freed, and their total size in bytes. This includes the memory of BufferT, BufferTArray, KeyT and CallMDesc structures
+// that have not been released with their Free() methods or by their finalizers (which run only after the structure is
+// garbage collected), as well as temporary memory used for the duration of calls. It does not include memory allocated
+// by the YottaDB engine itself.
+//
+// Comparing the values over time shows whether C memory is being returned, as that memory is not visible in Go's
+// runtime.MemStats. SweepCFrees() can be used to first release the memory of structures that are no longer referenced.
+func PendingCFrees() (allocs uint64, bytes uint64) {
+	return MemStats().Total.InUse()
+}
+
+// MemStats is a function to return the counts of C memory allocations made and freed by the wrapper, broken down by what
+// the memory is used for. This complements Go's runtime.MemStats, which cannot see C allocations. Memory allocated by
+// the YottaDB engine itself is not included. See also PendingCFrees().
+func MemStats() MemStatsT {
+	var stats [memCatCount]MemCounts
+	var retval MemStatsT
+
+	for i := range cMemCounts {
+		// Read the free counters first so a concurrent allocate/free pair cannot make the counts appear negative
+		stats[i].Frees = atomic.LoadUint64(&cMemCounts[i].Frees)
+		stats[i].FreeBytes = atomic.LoadUint64(&cMemCounts[i].FreeBytes)
+		stats[i].Allocs = atomic.LoadUint64(&cMemCounts[i].Allocs)
+		stats[i].AllocBytes = atomic.LoadUint64(&cMemCounts[i].AllocBytes)
+		retval.Total.Allocs += stats[i].Allocs
+		retval.Total.Frees += stats[i].Frees
+		retval.Total.AllocBytes += stats[i].AllocBytes
+		retval.Total.FreeBytes += stats[i].FreeBytes
+	}
+	retval.BufferT = stats[memCatBufferT]
+	retval.BufferTArray = stats[memCatBufferTArray]
+	retval.CallMDesc = stats[memCatCallMDesc]
+	retval.Parms = stats[memCatParms]
+	retval.Messages = stats[memCatMessages]
+	return retval
+}
+
+// InUse is a method to return the number of allocations not yet freed and their total size in bytes.
+func (counts MemCounts) InUse() (allocs uint64, bytes uint64) {
+	return counts.Allocs - counts.Frees, counts.AllocBytes - counts.FreeBytes
+}
+
+// SweepCFrees is a function to force the release of C memory owned by structures that are no longer referenced, rather than
+// waiting for the garbage collector to get around to it. It runs garbage collections, waiting after each one for the
+// finalizers it queued to run, until the number of pending allocations stops dropping. It returns the same values as
+// PendingCFrees() once done. Since it runs full garbage collections, it is best used in tests, diagnostics or at shutdown
+// (see SetSweepCFreesOnExit()) rather than routinely.
+func SweepCFrees() (allocs uint64, bytes uint64) {
+	printEntry("SweepCFrees()")
+	for i := 0; i < maxSweepPasses; i++ {
+		before, _ := PendingCFrees()
+		// Finalizers run one at a time in a single goroutine, so once a finalizer for an object made garbage before this
+		// collection has run, those queued ahead of it have most likely run too.
+		done := make(chan struct{})
+		sentinel := &struct{ ptr *byte }{} // Contains a pointer so it is not a "tiny" allocation that may never finalize
+		runtime.SetFinalizer(sentinel, func(*struct{ ptr *byte }) { close(done) })
+		sentinel = nil
+		runtime.GC()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+		}
+		if after, _ := PendingCFrees(); after >= before {
+			break
+		}
+	}
+	return PendingCFrees()
+}
+
+// SetSweepCFreesOnExit is a function to enable (or disable) a call to SweepCFrees() at the start of Exit(), so the C memory
+// of structures that are no longer referenced is released before the engine is shut down.
+func SetSweepCFreesOnExit(enable bool) {
+	var flag uint32
+
+	if enable {
+		flag = 1
+	}
+	atomic.StoreUint32(&cSweepOnExit, flag)
 }
 
 // errorFormat is a function to replace the FAO codes in YDB error messages with meaningful data. This is normally
@@ -203,6 +336,9 @@ func Exit() error {
 	if dbgSigHandling {
 		fmt.Fprintln(os.Stderr, "YDB: Exit(): YDB Engine shutdown started")
 	}
+	if 1 == atomic.LoadUint32(&cSweepOnExit) {
+		_, _ = SweepCFrees()
+	}
 	// When we run ydb_exit(), set up a timer that will pop if ydb_exit() gets stuck in a deadlock or whatever. We could
 	// be running after some fatal error has occurred so things could potentially be fairly screwed up and ydb_exit() may
 	// not be able to get the lock. We'll give it the given amount of time to finish before we give up and just exit.
@@ -253,7 +389,7 @@ func Exit() error {
 	// just go straight to getting the CALLINAFTERXIT error when an actual call is attempted. We now handle CALLINAFTERXIT
 	// in the places it matters.
 	if "" != errstr {
-		return &YDBError{errNum, errstr}
+		return &YDBError{errcode: errNum, errmsg: errstr}
 	}
 	return nil
 }
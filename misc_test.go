@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -226,3 +226,66 @@ func TestMaximumSigShutDownWait(t *testing.T) {
 func TestMaximumSigAckWait(t *testing.T) {
 	testTimerParameter(t, &yottadb.MaximumSigAckWait, yottadb.DefaultMaximumSigAckWait)
 }
+
+func TestMiscPendingCFrees(t *testing.T) {
+	var buft yottadb.BufferT
+
+	// Release what we can so other tests' garbage doesn't disturb the counts
+	allocsBefore, bytesBefore := yottadb.SweepCFrees()
+	buft.Alloc(1000)
+	allocs, bytes := yottadb.PendingCFrees()
+	assert.Equal(t, allocsBefore+2, allocs) // The ydb_buffer_t and its buffer
+	assert.Less(t, bytesBefore+1000, bytes)
+	buft.Free()
+	allocs, bytes = yottadb.PendingCFrees()
+	assert.Equal(t, allocsBefore, allocs)
+	assert.Equal(t, bytesBefore, bytes)
+	// An unreferenced structure is released by a sweep
+	func() {
+		var unreferenced yottadb.BufferT
+		unreferenced.Alloc(1000)
+	}()
+	allocs, _ = yottadb.SweepCFrees()
+	assert.Equal(t, allocsBefore, allocs)
+}
+
+func TestMiscMemStats(t *testing.T) {
+	var key yottadb.KeyT
+
+	before := yottadb.MemStats()
+	key.Alloc(8, 2, 100)
+	during := yottadb.MemStats()
+	// The variable name is in a BufferT and the subscripts in a BufferTArray
+	allocs, bytes := during.BufferT.InUse()
+	beforeAllocs, beforeBytes := before.BufferT.InUse()
+	assert.Equal(t, beforeAllocs+2, allocs)
+	assert.Less(t, beforeBytes+8, bytes)
+	allocs, bytes = during.BufferTArray.InUse()
+	beforeAllocs, beforeBytes = before.BufferTArray.InUse()
+	assert.Equal(t, beforeAllocs+3, allocs)
+	assert.Less(t, beforeBytes+200, bytes)
+	// The total is the sum of the categories
+	sum := during.BufferT.AllocBytes + during.BufferTArray.AllocBytes + during.CallMDesc.AllocBytes +
+		during.Parms.AllocBytes + during.Messages.AllocBytes
+	assert.Equal(t, sum, during.Total.AllocBytes)
+	key.Free()
+	after := yottadb.MemStats()
+	assert.Equal(t, before.BufferT.AllocBytes+(after.BufferT.FreeBytes-before.BufferT.FreeBytes), after.BufferT.AllocBytes)
+	// Arrays of empty buffers allocate only the array, and free all they allocate
+	var buftary yottadb.BufferTArray
+	buftary.Alloc(3, 0)
+	allocs, _ = yottadb.MemStats().BufferTArray.InUse()
+	beforeAllocs, _ = after.BufferTArray.InUse()
+	assert.Equal(t, beforeAllocs+1, allocs)
+	buftary.Free()
+	allocs, _ = yottadb.MemStats().BufferTArray.InUse()
+	assert.Equal(t, beforeAllocs, allocs)
+	// Calls into YottaDB free the parameters they allocate
+	err := yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		return yottadb.YDB_OK
+	}, "MEMSTATS", []string{})
+	Assertnoerr(err, t)
+	allocs, _ = yottadb.MemStats().Parms.InUse()
+	beforeAllocs, _ = after.Parms.InUse()
+	assert.Equal(t, beforeAllocs, allocs)
+}
@@ -0,0 +1,68 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"time"
+)
+
+// Subscripts below a node of the node holding the time the node was last set by SetValTrackedE()
+const (
+	MetaSubscript    = "_meta"
+	ModTimeSubscript = "mtime"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API functions tracking the last modification time of nodes
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SetValTrackedE is an Easy API function to set the value of varname(subary...) as SetValE() does and record the time of
+// the set in varname(subary...,MetaSubscript,ModTimeSubscript), i.e. varname(subary...,"_meta","mtime"), for ModTimeE()
+// to return. The time is recorded in UTC in RFC 3339 format with nanoseconds.
+//
+// The value and the time are set in one transaction, so the recorded time is never older than the value. SetValTrackedE()
+// may be called within a transaction, in which case tptoken must be the token of that transaction. Only sets made through
+// SetValTrackedE() are tracked: updates made by other functions or by M code leave the recorded time unchanged.
+func SetValTrackedE(tptoken uint64, errstr *BufferT, value, varname string, subary []string) error {
+	var metasubs []string
+
+	printEntry("SetValTrackedE()")
+	metasubs = append(append(metasubs, subary...), MetaSubscript, ModTimeSubscript)
+	_, err := TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (struct{}, error) {
+		if err := SetValE(tptoken, errstr, value, varname, subary); nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, SetValE(tptoken, errstr, time.Now().UTC().Format(time.RFC3339Nano), varname, metasubs)
+	}, "", []string{})
+	return err
+}
+
+// ModTimeE is an Easy API function to return the time varname(subary...) was last set by SetValTrackedE(), and whether a
+// time is recorded. If no time is recorded, ModTimeE() returns the zero time.Time and false.
+func ModTimeE(tptoken uint64, errstr *BufferT, varname string, subary []string) (time.Time, bool, error) {
+	var metasubs []string
+
+	printEntry("ModTimeE()")
+	metasubs = append(append(metasubs, subary...), MetaSubscript, ModTimeSubscript)
+	value, state, err := LookupE(tptoken, errstr, varname, metasubs)
+	if nil != err || LookupHasValue != state {
+		return time.Time{}, false, err
+	}
+	mtime, err := time.Parse(time.RFC3339Nano, value)
+	if nil != err {
+		return time.Time{}, false, err
+	}
+	return mtime, true, nil
+}
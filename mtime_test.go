@@ -0,0 +1,49 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+	"time"
+)
+
+func TestSetValTrackedE(t *testing.T) {
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^mtime", []string{})
+	_, tracked, err := yottadb.ModTimeE(yottadb.NOTTP, nil, "^mtime", []string{"order1"})
+	Assertnoerr(err, t)
+	assert.False(t, tracked)
+
+	before := time.Now()
+	Assertnoerr(yottadb.SetValTrackedE(yottadb.NOTTP, nil, "new", "^mtime", []string{"order1"}), t)
+	mtime, tracked, err := yottadb.ModTimeE(yottadb.NOTTP, nil, "^mtime", []string{"order1"})
+	Assertnoerr(err, t)
+	assert.True(t, tracked)
+	assert.False(t, mtime.Before(before))
+	assert.False(t, mtime.After(time.Now()))
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^mtime", []string{"order1", "_meta", "mtime"})
+	Assertnoerr(err, t)
+	assert.Equal(t, mtime.Format(time.RFC3339Nano), val)
+
+	// A later set records a later time, but untracked sets do not
+	Assertnoerr(yottadb.SetValTrackedE(yottadb.NOTTP, nil, "paid", "^mtime", []string{"order1"}), t)
+	later, _, err := yottadb.ModTimeE(yottadb.NOTTP, nil, "^mtime", []string{"order1"})
+	Assertnoerr(err, t)
+	assert.False(t, later.Before(mtime))
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "shipped", "^mtime", []string{"order1"}), t)
+	unchanged, _, err := yottadb.ModTimeE(yottadb.NOTTP, nil, "^mtime", []string{"order1"})
+	Assertnoerr(err, t)
+	assert.Equal(t, later, unchanged)
+}
@@ -0,0 +1,56 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API function for exactly-once processing
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// OnceE is an Easy API function to call fn in a transaction unless key was already processed, and return whether fn was
+// called. It is intended for idempotency keys, such as the IDs of messages from a queue that may deliver a message more
+// than once.
+//
+// The processed keys are recorded as subscripts of varname(subary...), normally a global variable: OnceE() sets
+// varname(subary...,key) to the UTC time fn was called in RFC 3339 format, in the same transaction as the updates made by
+// fn. Either the updates and the key are committed together, or neither is, so a key is never recorded for updates that
+// were lost or left unrecorded for updates that were committed. Old keys may be deleted by the application, for example by
+// their recorded time, after which their messages would be processed again.
+//
+// As with TpValE(), fn may be called more than once if the transaction restarts and an error returned by fn rolls back
+// the transaction and is returned by OnceE(), with the key left unrecorded so that a later call processes it again.
+func OnceE(tptoken uint64, errstr *BufferT, key string, fn func(tptoken uint64, errstr *BufferT) error, varname string,
+	subary []string) (bool, error) {
+	var keysubs []string
+
+	printEntry("OnceE()")
+	keysubs = append(append(keysubs, subary...), key)
+	return TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (bool, error) {
+		data, err := DataE(tptoken, errstr, varname, keysubs)
+		if nil != err {
+			return false, err
+		}
+		if 0 != data {
+			return false, nil
+		}
+		if err = fn(tptoken, errstr); nil != err {
+			return false, err
+		}
+		return true, SetValE(tptoken, errstr, time.Now().UTC().Format(time.RFC3339), varname, keysubs)
+	}, "", []string{})
+}
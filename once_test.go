@@ -0,0 +1,64 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+	"time"
+)
+
+func TestOnceE(t *testing.T) {
+	var errstr yottadb.BufferT
+	var calls int
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^once", []string{})
+	process := func(tptoken uint64, errstr *yottadb.BufferT) error {
+		calls++
+		_, err := yottadb.IncrE(tptoken, errstr, "1", "^once", []string{"count"})
+		return err
+	}
+	ran, err := yottadb.OnceE(yottadb.NOTTP, &errstr, "msg1", process, "^once", []string{"keys"})
+	Assertnoerr(err, t)
+	assert.True(t, ran)
+	ran, err = yottadb.OnceE(yottadb.NOTTP, &errstr, "msg1", process, "^once", []string{"keys"})
+	Assertnoerr(err, t)
+	assert.False(t, ran)
+	assert.Equal(t, 1, calls)
+	value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^once", []string{"keys", "msg1"})
+	Assertnoerr(err, t)
+	_, err = time.Parse(time.RFC3339, value)
+	Assertnoerr(err, t)
+	// An error from fn rolls back its updates and leaves the key to be processed again
+	errFail := errors.New("processing failed")
+	ran, err = yottadb.OnceE(yottadb.NOTTP, &errstr, "msg2", func(tptoken uint64, errstr *yottadb.BufferT) error {
+		Assertnoerr(process(tptoken, errstr), t)
+		return errFail
+	}, "^once", []string{"keys"})
+	assert.Equal(t, errFail, err)
+	assert.False(t, ran)
+	value, err = yottadb.ValE(yottadb.NOTTP, &errstr, "^once", []string{"count"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "1", value)
+	ran, err = yottadb.OnceE(yottadb.NOTTP, &errstr, "msg2", process, "^once", []string{"keys"})
+	Assertnoerr(err, t)
+	assert.True(t, ran)
+	value, err = yottadb.ValE(yottadb.NOTTP, &errstr, "^once", []string{"count"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "2", value)
+}
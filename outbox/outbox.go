@@ -0,0 +1,134 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package outbox implements the transactional outbox pattern on YottaDB, for services that update the database and also
+publish events to a message broker such as Kafka or NATS.
+
+Updating the database and publishing an event separately loses the event or publishes an event for an update that was
+rolled back if the process fails between the two. Instead, the application calls Enqueue() in the transaction that makes
+the updates, so the event is stored if and only if the updates commit, and a relay running Run() publishes the stored
+events and deletes each one once it is published. Events are stored in ^outbox("event",id) nodes, where id is a sequence
+number taken from ^outbox("seq"), and are published in id order.
+
+For example:
+
+	box := outbox.New()
+	err := yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		... // Update the database
+		if err := box.Enqueue(tptoken, errstr, []byte(`{"order":42,"status":"paid"}`)); nil != err {
+			return int32(yottadb.ErrorCode(err))
+		}
+		return yottadb.YDB_OK
+	}, "", nil)
+	...
+	// In the relay
+	err = box.Run(ctx, time.Second, func(id string, event []byte) error {
+		return producer.Publish(id, event)
+	})
+
+Each event is published at least once: if the relay fails after publishing an event but before deleting it, the event is
+published again when the relay restarts. Consumers that need exactly-once processing should discard events whose id they
+have already processed, for example with yottadb.OnceE(). Run() holds the YottaDB lock ^outbox while it runs, so only one
+relay publishes at a time and events are published in order; as YottaDB locks are not replicated, all the relays must use
+the same database instance.
+*/
+package outbox
+
+import (
+	"context"
+	"lang.yottadb.com/go/yottadb"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() stores events in
+const DefaultGlobal string = "^outbox"
+
+// Outbox is an outbox storing events in Global("event",id) nodes
+type Outbox struct {
+	Global string // Global variable name holding events, e.g. "^outbox"
+}
+
+// New is a function to return the outbox stored in DefaultGlobal
+func New() *Outbox {
+	return &Outbox{Global: DefaultGlobal}
+}
+
+// Enqueue is a method to store an event for publishing, and is intended to be called within the transaction whose updates
+// the event reports, with the tptoken of that transaction
+func (box *Outbox) Enqueue(tptoken uint64, errstr *yottadb.BufferT, event []byte) error {
+	id, err := yottadb.IncrE(tptoken, errstr, "1", box.Global, []string{"seq"})
+	if nil != err {
+		return err
+	}
+	return yottadb.SetValE(tptoken, errstr, string(event), box.Global, []string{"event", id})
+}
+
+// Run is a method to publish the stored events with publish, in id order, deleting each event once publish returns nil for
+// it, and then to wait for new events, checking every poll (which must be positive). Run() first waits for any other relay
+// to finish, and returns when ctx is done, with the error of ctx, or when publish returns an error, with that error and the
+// event left stored to be published again.
+func (box *Outbox) Run(ctx context.Context, poll time.Duration, publish func(id string, event []byte) error) error {
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	for {
+		err := yottadb.LockIncrE(yottadb.NOTTP, nil, 0, box.Global, []string{})
+		if nil == err {
+			break
+		}
+		if yottadb.YDB_LOCK_TIMEOUT != yottadb.ErrorCode(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	defer yottadb.LockDecrE(yottadb.NOTTP, nil, box.Global, []string{})
+	for {
+		if err := box.publishAll(ctx, publish); nil != err {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishAll is a method to publish and delete the stored events until none are left
+func (box *Outbox) publishAll(ctx context.Context, publish func(id string, event []byte) error) error {
+	subary := []string{"event", ""}
+	for nil == ctx.Err() {
+		id, err := yottadb.SubNextE(yottadb.NOTTP, nil, box.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		subary[1] = id
+		event, err := yottadb.ValE(yottadb.NOTTP, nil, box.Global, subary)
+		if nil != err {
+			return err
+		}
+		if err = publish(id, []byte(event)); nil != err {
+			return err
+		}
+		if err = yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, box.Global, subary); nil != err {
+			return err
+		}
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,80 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/outbox"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestOutbox(t *testing.T) {
+	var published []string
+
+	box := outbox.New()
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, box.Global, []string{})
+	enqueue := func(event string, commit bool) {
+		err := yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+			Assertnoerr(box.Enqueue(tptoken, errstr, []byte(event)), t)
+			if !commit {
+				return yottadb.YDB_TP_ROLLBACK
+			}
+			return yottadb.YDB_OK
+		}, "", nil)
+		if commit {
+			Assertnoerr(err, t)
+		}
+	}
+	enqueue("e1", true)
+	enqueue("rolled back", false)
+	enqueue("e2", true)
+	// A publish error stops the relay and leaves the event to be published again
+	errBroker := errors.New("broker unavailable")
+	err := box.Run(context.Background(), time.Millisecond, func(id string, event []byte) error {
+		if "e2" == string(event) {
+			return errBroker
+		}
+		published = append(published, id+"="+string(event))
+		return nil
+	})
+	assert.Equal(t, errBroker, err)
+	assert.Equal(t, []string{"1=e1"}, published)
+	// Events enqueued while the relay runs are published too, in id order
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- box.Run(ctx, time.Millisecond, func(id string, event []byte) error {
+			published = append(published, id+"="+string(event))
+			if "e3" == string(event) {
+				cancel()
+			}
+			return nil
+		})
+	}()
+	enqueue("e3", true)
+	assert.Equal(t, context.Canceled, <-done)
+	assert.Equal(t, []string{"1=e1", "2=e2", "3=e3"}, published)
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, box.Global, []string{"event"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
@@ -0,0 +1,155 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Operations that can be recorded in a Pipeline
+const (
+	pipeOpSetVal int = iota
+	pipeOpVal
+	pipeOpIncr
+	pipeOpData
+	pipeOpDelete
+)
+
+// pipelineOp is a structure describing one recorded operation of a Pipeline
+type pipelineOp struct {
+	op      int      // Operation (pipeOp*)
+	value   string   // Value for pipeOpSetVal or increment for pipeOpIncr
+	deltype int      // Deletion type for pipeOpDelete
+	varname string   // Variable name the operation applies to
+	subary  []string // Subscripts of the node the operation applies to
+}
+
+// Pipeline is a structure that records a sequence of Easy API operations to be executed together, in order, as a single
+// transaction by ExecE(). The zero value is an empty Pipeline ready for use. For example:
+//
+//	var pipe yottadb.Pipeline
+//	pipe.SetVal("Joe", "^customer", []string{"1", "name"})
+//	balance := pipe.Incr("-10", "^customer", []string{"1", "balance"})
+//	results, err := pipe.ExecE(yottadb.NOTTP, nil)
+//	// results[balance] holds the new balance
+//
+// Executing the operations in one transaction means they are applied atomically, with no other process seeing some but not
+// all of their updates, at the cost of a single transaction. A Pipeline is not safe for concurrent use by multiple goroutines.
+type Pipeline struct {
+	ops []pipelineOp
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Methods to record operations in a Pipeline. Each returns the index of its result in the results
+// returned by ExecE().
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SetVal is a method to record setting the value of varname(subary...), as SetValE() does. Its result is empty.
+func (pipe *Pipeline) SetVal(value, varname string, subary []string) int {
+	return pipe.record(pipelineOp{op: pipeOpSetVal, value: value, varname: varname, subary: subary})
+}
+
+// Val is a method to record fetching the value of varname(subary...), as ValE() does. Its result is the value.
+func (pipe *Pipeline) Val(varname string, subary []string) int {
+	return pipe.record(pipelineOp{op: pipeOpVal, varname: varname, subary: subary})
+}
+
+// Incr is a method to record incrementing varname(subary...), as IncrE() does. Its result is the incremented value.
+func (pipe *Pipeline) Incr(incr, varname string, subary []string) int {
+	return pipe.record(pipelineOp{op: pipeOpIncr, value: incr, varname: varname, subary: subary})
+}
+
+// Data is a method to record fetching $DATA() of varname(subary...), as DataE() does. Its result is the $DATA() value as a
+// decimal string (e.g. "10").
+func (pipe *Pipeline) Data(varname string, subary []string) int {
+	return pipe.record(pipelineOp{op: pipeOpData, varname: varname, subary: subary})
+}
+
+// Delete is a method to record deleting a node (YDB_DEL_NODE) or tree (YDB_DEL_TREE), as DeleteE() does. Its result is
+// empty.
+func (pipe *Pipeline) Delete(deltype int, varname string, subary []string) int {
+	return pipe.record(pipelineOp{op: pipeOpDelete, deltype: deltype, varname: varname, subary: subary})
+}
+
+// Len is a method to return the number of operations recorded in the Pipeline.
+func (pipe *Pipeline) Len() int {
+	return len(pipe.ops)
+}
+
+// Reset is a method to remove all operations recorded in the Pipeline so it can be reused.
+func (pipe *Pipeline) Reset() {
+	pipe.ops = pipe.ops[:0]
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Pipeline execution
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// ExecE is an Easy API method to execute the operations recorded in the Pipeline, in order, in a single transaction (using
+// TpValE()). The returned slice holds the result of each operation at the index returned when the operation was recorded.
+// The recorded operations are kept, so the Pipeline may be executed again or cleared with Reset().
+//
+// If an operation returns an error (for example, GVUNDEF from a Val() of an undefined node), the transaction is rolled back
+// so none of the updates of the Pipeline are applied, and ExecE() returns nil results and the error. Restarts of the
+// transaction are handled by re-executing all the operations. ExecE() may itself be called within a transaction, in which
+// case tptoken must be the token of that transaction.
+func (pipe *Pipeline) ExecE(tptoken uint64, errstr *BufferT) ([]string, error) {
+	printEntry("Pipeline.ExecE()")
+	if nil == pipe {
+		panic("YDB: *Pipeline receiver of ExecE() cannot be nil")
+	}
+	return TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) ([]string, error) {
+		var err error
+		var data uint32
+
+		results := make([]string, len(pipe.ops))
+		for i := range pipe.ops {
+			op := &pipe.ops[i]
+			switch op.op {
+			case pipeOpSetVal:
+				err = SetValE(tptoken, errstr, op.value, op.varname, op.subary)
+			case pipeOpVal:
+				results[i], err = ValE(tptoken, errstr, op.varname, op.subary)
+			case pipeOpIncr:
+				results[i], err = IncrE(tptoken, errstr, op.value, op.varname, op.subary)
+			case pipeOpData:
+				data, err = DataE(tptoken, errstr, op.varname, op.subary)
+				results[i] = strconv.FormatUint(uint64(data), 10)
+			case pipeOpDelete:
+				err = DeleteE(tptoken, errstr, op.deltype, op.varname, op.subary)
+			default:
+				panic(fmt.Sprintf("YDB: Unknown pipeline operation %d", op.op))
+			}
+			if nil != err {
+				return nil, err
+			}
+		}
+		return results, nil
+	}, "", []string{})
+}
+
+// record is a method to add an operation to the Pipeline and return its index.
+func (pipe *Pipeline) record(op pipelineOp) int {
+	if nil == pipe {
+		panic("YDB: *Pipeline receiver cannot be nil")
+	}
+	// Copy the subscripts so later changes by the caller to the slice do not change the recorded operation
+	op.subary = append([]string(nil), op.subary...)
+	pipe.ops = append(pipe.ops, op)
+	return len(pipe.ops) - 1
+}
@@ -0,0 +1,58 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestPipeline(t *testing.T) {
+	var errstr yottadb.BufferT
+	var pipe yottadb.Pipeline
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	subs := []string{"a"}
+	set := pipe.SetVal("1", "^pipeline", subs)
+	subs[0] = "b" // Changing the caller's slice must not change the recorded operation
+	incr := pipe.Incr("5", "^pipeline", []string{"a"})
+	data := pipe.Data("^pipeline", []string{})
+	val := pipe.Val("^pipeline", []string{"a"})
+	del := pipe.Delete(yottadb.YDB_DEL_NODE, "^pipeline", []string{"a"})
+	assert.Equal(t, 5, pipe.Len())
+	results, err := pipe.ExecE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, 5, len(results))
+	assert.Equal(t, "", results[set])
+	assert.Equal(t, "6", results[incr])
+	assert.Equal(t, "10", results[data])
+	assert.Equal(t, "6", results[val])
+	assert.Equal(t, "", results[del])
+	dataval, err := yottadb.DataE(yottadb.NOTTP, &errstr, "^pipeline", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), dataval)
+	// An error rolls back the updates of all the operations
+	pipe.Reset()
+	assert.Equal(t, 0, pipe.Len())
+	pipe.SetVal("1", "^pipeline", []string{"a"})
+	pipe.Val("^pipeline", []string{"undefined"})
+	results, err = pipe.ExecE(yottadb.NOTTP, &errstr)
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	assert.Nil(t, results)
+	dataval, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^pipeline", []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), dataval)
+}
@@ -0,0 +1,123 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Defaults used by a RetryPolicy for fields left as zero
+const (
+	DefaultRetryAttempts int           = 5                      // Default value of RetryPolicy.MaxAttempts
+	DefaultRetryDelay    time.Duration = 10 * time.Millisecond  // Default value of RetryPolicy.Delay
+	DefaultRetryMaxDelay time.Duration = 500 * time.Millisecond // Default value of RetryPolicy.MaxDelay
+)
+
+// RetryPolicy is a structure that describes how operations failing with transient errors (for example a lock that could
+// not be acquired within its timeout, or a journal file that could not be opened while it is being switched) are retried
+// by its Do() method. It also counts the calls and retries it has made, returned by its Stats() method. A RetryPolicy may
+// be shared by multiple goroutines but must not be copied once in use. For example:
+//
+//	var policy = yottadb.RetryPolicy{MaxAttempts: 3}
+//	err := policy.Do(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) error {
+//		return yottadb.LockIncrE(tptoken, errstr, 100000000, "^account", []string{"1"})
+//	})
+type RetryPolicy struct {
+	calls     uint64 // Count of calls to Do() - kept first for 64 bit alignment of atomic access on 32 bit platforms
+	retries   uint64 // Count of retries made by Do()
+	exhausted uint64 // Count of calls to Do() that returned a transient error after MaxAttempts attempts
+
+	MaxAttempts int                  // Maximum number of attempts including the first (0 means DefaultRetryAttempts)
+	Delay       time.Duration        // Delay before the first retry, doubled for each retry (0 means DefaultRetryDelay)
+	MaxDelay    time.Duration        // Maximum delay between retries (0 means DefaultRetryMaxDelay)
+	Retryable   func(err error) bool // Function to decide whether an error is transient (nil means IsTransientError)
+}
+
+// RetryStats is a structure containing the counts returned by RetryPolicy.Stats()
+type RetryStats struct {
+	Calls     uint64 // Number of calls to Do()
+	Retries   uint64 // Number of retries made, in addition to the first attempt of each call
+	Exhausted uint64 // Number of calls that returned a transient error after running out of attempts
+}
+
+// Do is a method to call fn, passing it tptoken and errstr, until it succeeds, returns an error that is not transient, or
+// has been called MaxAttempts times. It returns the error from the last call. Between calls, it sleeps for Delay, doubling
+// the delay for each retry up to MaxDelay.
+//
+// Retrying is only appropriate for operations that are safe to repeat. Inside a transaction, the transaction itself should
+// be restarted instead, so Do() makes a single attempt if tptoken is not NOTTP.
+func (policy *RetryPolicy) Do(tptoken uint64, errstr *BufferT, fn func(uint64, *BufferT) error) error {
+	printEntry("RetryPolicy.Do()")
+	if nil == policy {
+		panic("YDB: *RetryPolicy receiver of Do() cannot be nil")
+	}
+	maxAttempts := policy.MaxAttempts
+	if 0 >= maxAttempts {
+		maxAttempts = DefaultRetryAttempts
+	}
+	if NOTTP != tptoken {
+		maxAttempts = 1
+	}
+	delay := policy.Delay
+	if 0 >= delay {
+		delay = DefaultRetryDelay
+	}
+	maxDelay := policy.MaxDelay
+	if 0 >= maxDelay {
+		maxDelay = DefaultRetryMaxDelay
+	}
+	retryable := policy.Retryable
+	if nil == retryable {
+		retryable = IsTransientError
+	}
+	atomic.AddUint64(&policy.calls, 1)
+	for attempt := 1; ; attempt++ {
+		err := fn(tptoken, errstr)
+		if nil == err || !retryable(err) {
+			return err
+		}
+		if maxAttempts <= attempt {
+			atomic.AddUint64(&policy.exhausted, 1)
+			return err
+		}
+		atomic.AddUint64(&policy.retries, 1)
+		time.Sleep(delay)
+		delay *= 2
+		if maxDelay < delay {
+			delay = maxDelay
+		}
+	}
+}
+
+// Stats is a method to return the counts of the calls and retries made by the policy.
+func (policy *RetryPolicy) Stats() RetryStats {
+	return RetryStats{
+		Calls:     atomic.LoadUint64(&policy.calls),
+		Retries:   atomic.LoadUint64(&policy.retries),
+		Exhausted: atomic.LoadUint64(&policy.exhausted),
+	}
+}
+
+// IsTransientError is a function to report whether an error is one that may not recur if the operation is retried after a
+// short delay, the default test used by RetryPolicy. These are lock timeouts (YDB_LOCK_TIMEOUT), a full lock space
+// (LOCKSPACEFULL), journal files that cannot be accessed while being switched (JNLFILOPN, JNLACCESS and JNLSWITCHFAIL) and
+// a critical section reset by another process (CRITRESET).
+func IsTransientError(err error) bool {
+	switch ErrorCode(err) {
+	case YDB_LOCK_TIMEOUT, YDB_ERR_LOCKSPACEFULL, YDB_ERR_JNLFILOPN, YDB_ERR_JNLACCESS, YDB_ERR_JNLSWITCHFAIL,
+		YDB_ERR_CRITRESET:
+		return true
+	}
+	return false
+}
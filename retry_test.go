@@ -0,0 +1,65 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	var attempts int
+
+	policy := yottadb.RetryPolicy{MaxAttempts: 3, Delay: time.Millisecond}
+	lockTimeout := yottadb.NewError(yottadb.NOTTP, nil, yottadb.YDB_LOCK_TIMEOUT)
+	assert.True(t, yottadb.IsTransientError(lockTimeout))
+	// Succeeds on the second attempt
+	err := policy.Do(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) error {
+		attempts++
+		if 1 == attempts {
+			return lockTimeout
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	// Runs out of attempts
+	attempts = 0
+	err = policy.Do(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) error {
+		attempts++
+		return lockTimeout
+	})
+	assert.Equal(t, lockTimeout, err)
+	assert.Equal(t, 3, attempts)
+	// Errors that are not transient are not retried
+	attempts = 0
+	errOther := errors.New("not transient")
+	err = policy.Do(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) error {
+		attempts++
+		return errOther
+	})
+	assert.Equal(t, errOther, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, yottadb.RetryStats{Calls: 3, Retries: 3, Exhausted: 1}, policy.Stats())
+	// Operations that succeed return immediately
+	lockPolicy := yottadb.RetryPolicy{MaxAttempts: 2, Delay: time.Millisecond}
+	err = lockPolicy.Do(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) error {
+		return yottadb.LockIncrE(tptoken, errstr, 0, "^retrylock", []string{})
+	})
+	assert.Nil(t, err)
+	err = yottadb.LockDecrE(yottadb.NOTTP, nil, "^retrylock", []string{})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,150 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// sampleRingSize is the number of most recent samples kept by the access sampler
+const sampleRingSize int = 4096
+
+// maxSampleDepth is the number of leading subscripts of a node recorded in a sample
+const maxSampleDepth int = 8
+
+// accessSample is a structure holding a sampled access to a global variable node
+type accessSample struct {
+	varname string   // Name of the global variable, including the caret
+	subary  []string // Up to maxSampleDepth leading subscripts of the node
+}
+
+var sampleEvery uint64   // Atomic: Sample one of every sampleEvery accesses, or none if 0 (see EnableSampling())
+var sampleCounter uint64 // Atomic: Number of accesses counted towards sampling
+
+var sampleMutex sync.Mutex                  // Protects the fields below
+var sampleRing [sampleRingSize]accessSample // Most recent samples, oldest overwritten first
+var sampleNext int                          // Index in sampleRing of the next sample
+var sampleCount int                         // Number of samples in sampleRing
+
+// AccessCount is a structure holding the number of sampled accesses to a node or its descendants, as returned by
+// SampledAccesses()
+type AccessCount struct {
+	Node  string // Name of the node in ZWRITE format, e.g. ^orders("2024")
+	Count int    // Number of sampled accesses to the node or its descendants
+}
+
+// EnableSampling is a function to start recording one of every every accesses to global variable nodes made by the process
+// (or to stop if every is 0), to find out which global variables and subtrees are accessed most with SampledAccesses().
+// Fetching, setting, incrementing and deleting nodes, querying their existence and traversing subscripts and nodes count
+// as accesses; local variables are not sampled. The last 4096 samples are kept. Sampling costs an atomic addition per
+// access and the copy of the node's name per sample. When sampling is disabled, the cost is one atomic load per access.
+// EnableSampling() panics if every is negative.
+func EnableSampling(every int) {
+	if 0 > every {
+		panic("YDB: EnableSampling() called with a negative rate")
+	}
+	atomic.StoreUint64(&sampleEvery, uint64(every))
+	setHook(hookSample, 0 != every)
+}
+
+// ResetSamples is a function to discard all recorded samples.
+func ResetSamples() {
+	sampleMutex.Lock()
+	defer sampleMutex.Unlock()
+	sampleRing = [sampleRingSize]accessSample{}
+	sampleNext, sampleCount = 0, 0
+}
+
+// SampledAccesses is a function to return the number of recorded samples that accessed each node at the given depth of
+// subscripts or its descendants, most accessed first (and in ZWRITE name order for equal counts). A depth of 0 counts by
+// global variable name, 1 by the first subscript and so on; samples of nodes with fewer subscripts than depth count
+// towards the node itself. Depths greater than 8 count as 8.
+func SampledAccesses(depth int) []AccessCount {
+	var counts []AccessCount
+
+	if depth > maxSampleDepth {
+		depth = maxSampleDepth
+	}
+	index := make(map[string]int)
+	sampleMutex.Lock()
+	defer sampleMutex.Unlock()
+	for _, sample := range sampleRing[:sampleCount] {
+		subary := sample.subary
+		if depth < len(subary) {
+			subary = subary[:depth]
+		}
+		node := ZwriteName(sample.varname, subary)
+		if i, ok := index[node]; ok {
+			counts[i].Count++
+		} else {
+			index[node] = len(counts)
+			counts = append(counts, AccessCount{Node: node, Count: 1})
+		}
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Node < counts[j].Node
+	})
+	return counts
+}
+
+// sampleDue is a function to count an access towards sampling and return whether it is to be sampled
+func sampleDue() bool {
+	every := atomic.LoadUint64(&sampleEvery)
+	return 0 != every && 0 == atomic.AddUint64(&sampleCounter, 1)%every
+}
+
+// sampleNode is a function to count an access to varname(subary...) towards sampling and record it if it is to be sampled
+func sampleNode(varname string, subary []string) {
+	if 0 == len(varname) || '^' != varname[0] || !sampleDue() {
+		return
+	}
+	if maxSampleDepth < len(subary) {
+		subary = subary[:maxSampleDepth]
+	}
+	recordSample(accessSample{varname, append([]string(nil), subary...)})
+}
+
+// sampleAccess is a method to count an access to the key's node towards sampling and record it if it is to be sampled
+func (key *KeyT) sampleAccess() {
+	varnm := bufferBytes(key.Varnm.getCPtr())
+	if 0 == len(varnm) || '^' != varnm[0] || !sampleDue() {
+		return
+	}
+	nsubs := int(key.subscriptCount())
+	if maxSampleDepth < nsubs {
+		nsubs = maxSampleDepth
+	}
+	sample := accessSample{string(varnm), make([]string, nsubs)}
+	for i := range sample.subary {
+		sample.subary[i] = string(key.subscriptBytes(uint32(i)))
+	}
+	runtime.KeepAlive(key)
+	recordSample(sample)
+}
+
+// recordSample is a function to add a sample to the ring of samples
+func recordSample(sample accessSample) {
+	sampleMutex.Lock()
+	defer sampleMutex.Unlock()
+	sampleRing[sampleNext] = sample
+	sampleNext = (sampleNext + 1) % sampleRingSize
+	if sampleCount < sampleRingSize {
+		sampleCount++
+	}
+}
@@ -0,0 +1,58 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestSampledAccesses(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^sampled", []string{})
+	yottadb.ResetSamples()
+	yottadb.EnableSampling(1)
+	defer yottadb.EnableSampling(0)
+	for i := 0; i < 3; i++ {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", "^sampled", []string{"hot", "a"}), t)
+		_, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^sampled", []string{"hot", "b"})
+		assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	}
+	_, err := yottadb.DataE(yottadb.NOTTP, &errstr, "^sampled", []string{"cold"})
+	Assertnoerr(err, t)
+	// Local variables are not sampled
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", "sampledlocal", []string{}), t)
+	yottadb.EnableSampling(0)
+	_, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^sampled", []string{"cold"})
+	Assertnoerr(err, t)
+	assert.Equal(t, []yottadb.AccessCount{{Node: "^sampled", Count: 7}}, yottadb.SampledAccesses(0))
+	assert.Equal(t, []yottadb.AccessCount{{Node: `^sampled("hot")`, Count: 6}, {Node: `^sampled("cold")`, Count: 1}},
+		yottadb.SampledAccesses(1))
+	assert.Equal(t, []yottadb.AccessCount{{Node: `^sampled("hot","a")`, Count: 3}, {Node: `^sampled("hot","b")`, Count: 3},
+		{Node: `^sampled("cold")`, Count: 1}}, yottadb.SampledAccesses(5))
+	// Sampling one of every two accesses records half of them
+	yottadb.ResetSamples()
+	yottadb.EnableSampling(2)
+	for i := 0; i < 10; i++ {
+		_, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^sampled", []string{})
+		Assertnoerr(err, t)
+	}
+	yottadb.EnableSampling(0)
+	assert.Equal(t, []yottadb.AccessCount{{Node: "^sampled", Count: 5}}, yottadb.SampledAccesses(0))
+	assert.Panics(t, func() { yottadb.EnableSampling(-1) })
+}
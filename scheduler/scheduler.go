@@ -0,0 +1,263 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package scheduler runs recurring jobs at fixed intervals, with the schedule kept in YottaDB so that the processes sharing
+a database coordinate the jobs through the database itself: each run of a job is claimed by exactly one worker process.
+
+A job is stored in the ^scheduler("job",name,...) nodes as its interval, the time of its next run and the time its last
+run finished, with times in nanoseconds since the Unix epoch. Workers call Claim() periodically. Claim() finds a job that
+is due, takes the YottaDB lock ^scheduler("job",name) without waiting and advances the next run time of the job in a
+transaction, so no other worker can claim the same run. The lock is held while the job runs, until Done() is called, so
+a run that takes longer than the interval does not overlap the next one. As YottaDB locks belong to a process, which can
+take a lock it already holds, each process also records the jobs it is running, so that goroutines of the same process
+do not overlap either. YottaDB releases the locks of a process that exits, so a job whose worker crashes runs again at
+its next run time.
+
+For example:
+
+	sched := scheduler.New()
+	err := sched.Add(yottadb.NOTTP, nil, "purge-sessions", 15*time.Minute, time.Now())
+	...
+	// In each worker
+	for range time.Tick(time.Second) {
+		run, err := sched.Claim(yottadb.NOTTP, nil, time.Now())
+		...
+		if nil != run {
+			purge(run.Name)
+			err = run.Done(yottadb.NOTTP, nil)
+		}
+	}
+
+As YottaDB locks are not replicated, all the workers must use the same database instance.
+*/
+package scheduler
+
+import (
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() stores jobs in
+const DefaultGlobal string = "^scheduler"
+
+var runningMutex sync.Mutex         // Mutex for access to running
+var running = make(map[string]bool) // Jobs claimed in this process and not yet done, by the name of their lock
+
+// Scheduler is a set of recurring jobs stored in Global("job",name,...) nodes
+type Scheduler struct {
+	Global string // Global (or local) variable name holding jobs, e.g. "^scheduler"
+}
+
+// Job describes a job of a scheduler, as returned by Jobs()
+type Job struct {
+	Name     string        // Name of the job
+	Interval time.Duration // Time between the starts of successive runs
+	Next     time.Time     // Time of the next run
+	Last     time.Time     // Time the last run finished, or the zero time if it never ran
+}
+
+// Run is a run of a job claimed by Claim(), which must be passed to Done() when the job finishes
+type Run struct {
+	Name      string    // Name of the job
+	Scheduled time.Time // Time the run was scheduled for
+
+	sched *Scheduler
+}
+
+// New is a function to return the scheduler stored in DefaultGlobal
+func New() *Scheduler {
+	return &Scheduler{Global: DefaultGlobal}
+}
+
+// Add is a method to add a job with the given name that runs every interval, starting at first, or now if first is the zero
+// time. Adding a job that exists replaces its interval and next run time. Add() panics if interval is not positive.
+func (sched *Scheduler) Add(tptoken uint64, errstr *yottadb.BufferT, name string, interval time.Duration,
+	first time.Time) error {
+	if 0 >= interval {
+		panic(fmt.Sprintf("YDB: interval of job %s must be positive", name))
+	}
+	if first.IsZero() {
+		first = time.Now()
+	}
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		err := yottadb.SetValE(tptoken, errstr, strconv.FormatInt(int64(interval), 10), sched.Global,
+			[]string{"job", name, "interval"})
+		if nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, sched.setTime(tptoken, errstr, name, "next", first)
+	}, "", []string{})
+	return err
+}
+
+// Remove is a method to remove the job with the given name. A run of the job in progress is not affected.
+func (sched *Scheduler) Remove(tptoken uint64, errstr *yottadb.BufferT, name string) error {
+	return yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, sched.Global, []string{"job", name})
+}
+
+// Jobs is a method to call fn with each job of the scheduler, in name order. Iteration stops early if fn returns false.
+func (sched *Scheduler) Jobs(tptoken uint64, errstr *yottadb.BufferT, fn func(job Job) bool) error {
+	var joberr error
+
+	err := yottadb.SubRangeE(tptoken, errstr, sched.Global, []string{"job"}, "", "", func(name string) bool {
+		var job Job
+
+		job, joberr = sched.job(tptoken, errstr, name)
+		if undefined(joberr) {
+			joberr = nil // The job was removed
+			return true
+		}
+		return nil == joberr && fn(job)
+	})
+	if nil != joberr {
+		return joberr
+	}
+	return err
+}
+
+// Claim is a method to claim a run of a job that is due at now, and return it, or nil if no job is due or all the jobs
+// that are due are claimed by other workers. The next run time of the claimed job advances by whole intervals until it
+// is after now, so runs missed while no worker claimed the job are skipped rather than run in a burst.
+func (sched *Scheduler) Claim(tptoken uint64, errstr *yottadb.BufferT, now time.Time) (*Run, error) {
+	var run *Run
+	var claimerr error
+
+	err := yottadb.SubRangeE(tptoken, errstr, sched.Global, []string{"job"}, "", "", func(name string) bool {
+		run, claimerr = sched.claim(tptoken, errstr, name, now)
+		return nil == claimerr && nil == run
+	})
+	if nil != claimerr {
+		return nil, claimerr
+	}
+	return run, err
+}
+
+// Done is a method to record that the claimed run finished, and release the job for its next run
+func (run *Run) Done(tptoken uint64, errstr *yottadb.BufferT) error {
+	sched := run.sched
+	defer sched.release(run.Name)
+	defer yottadb.LockDecrE(tptoken, errstr, sched.Global, []string{"job", run.Name})
+	data, err := yottadb.DataE(tptoken, errstr, sched.Global, []string{"job", run.Name, "interval"})
+	if nil != err || 0 == data {
+		return err // The job was removed while it ran
+	}
+	return sched.setTime(tptoken, errstr, run.Name, "last", time.Now())
+}
+
+// claim is a method to claim a run of the named job if it is due at now, returning nil if it is not
+func (sched *Scheduler) claim(tptoken uint64, errstr *yottadb.BufferT, name string, now time.Time) (*Run, error) {
+	job, err := sched.job(tptoken, errstr, name)
+	if undefined(err) {
+		return nil, nil // The job was removed
+	}
+	if nil != err || job.Next.After(now) {
+		return nil, err
+	}
+	if !sched.reserve(name) {
+		return nil, nil // Another goroutine of this process is running the job
+	}
+	err = yottadb.LockIncrE(tptoken, errstr, 0, sched.Global, []string{"job", name})
+	if nil != err {
+		sched.release(name)
+		if yottadb.YDB_LOCK_TIMEOUT == yottadb.ErrorCode(err) {
+			return nil, nil // Another worker is running the job
+		}
+		return nil, err
+	}
+	// Check again in a transaction that the run is due, as another worker may have claimed it and finished since
+	scheduled, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (time.Time, error) {
+		job, err := sched.job(tptoken, errstr, name)
+		if undefined(err) {
+			return time.Time{}, nil
+		}
+		if nil != err || job.Next.After(now) {
+			return time.Time{}, err
+		}
+		skip := now.Sub(job.Next)/job.Interval + 1
+		return job.Next, sched.setTime(tptoken, errstr, name, "next", job.Next.Add(skip*job.Interval))
+	}, "", []string{})
+	if nil != err || scheduled.IsZero() {
+		yottadb.LockDecrE(tptoken, errstr, sched.Global, []string{"job", name})
+		sched.release(name)
+		return nil, err
+	}
+	return &Run{Name: name, Scheduled: scheduled, sched: sched}, nil
+}
+
+// reserve is a method to record that the named job is claimed in this process, and return false if it already is
+func (sched *Scheduler) reserve(name string) bool {
+	lock := yottadb.ZwriteName(sched.Global, []string{"job", name})
+	runningMutex.Lock()
+	defer runningMutex.Unlock()
+	if running[lock] {
+		return false
+	}
+	running[lock] = true
+	return true
+}
+
+// release is a method to record that the named job is no longer claimed in this process
+func (sched *Scheduler) release(name string) {
+	lock := yottadb.ZwriteName(sched.Global, []string{"job", name})
+	runningMutex.Lock()
+	delete(running, lock)
+	runningMutex.Unlock()
+}
+
+// job is a method to read the named job
+func (sched *Scheduler) job(tptoken uint64, errstr *yottadb.BufferT, name string) (Job, error) {
+	job := Job{Name: name}
+	value, err := yottadb.ValE(tptoken, errstr, sched.Global, []string{"job", name, "interval"})
+	if nil != err {
+		return job, err
+	}
+	interval, err := strconv.ParseInt(value, 10, 64)
+	if nil != err || 0 >= interval {
+		return job, fmt.Errorf("scheduler: invalid interval %q for job %s", value, name)
+	}
+	job.Interval = time.Duration(interval)
+	if job.Next, err = sched.time(tptoken, errstr, name, "next"); nil != err {
+		return job, err
+	}
+	job.Last, err = sched.time(tptoken, errstr, name, "last")
+	return job, err
+}
+
+// time is a method to read a time of the named job, returning the zero time if it is not set
+func (sched *Scheduler) time(tptoken uint64, errstr *yottadb.BufferT, name, field string) (time.Time, error) {
+	value, err := yottadb.ValE(tptoken, errstr, sched.Global, []string{"job", name, field})
+	if nil != err {
+		if undefined(err) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	nsec, err := strconv.ParseInt(value, 10, 64)
+	if nil != err {
+		return time.Time{}, fmt.Errorf("scheduler: invalid %s time %q for job %s", field, value, name)
+	}
+	return time.Unix(0, nsec), nil
+}
+
+// setTime is a method to set a time of the named job
+func (sched *Scheduler) setTime(tptoken uint64, errstr *yottadb.BufferT, name, field string, t time.Time) error {
+	return yottadb.SetValE(tptoken, errstr, strconv.FormatInt(t.UnixNano(), 10), sched.Global, []string{"job", name, field})
+}
+
+// undefined is a function to return whether err reports an undefined node
+func undefined(err error) bool {
+	return yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err)
+}
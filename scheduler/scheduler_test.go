@@ -0,0 +1,85 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package scheduler_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/scheduler"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestScheduler(t *testing.T) {
+	var jobs []scheduler.Job
+
+	sched := scheduler.New()
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, sched.Global, []string{})
+	start := time.Unix(1700000000, 0)
+	Assertnoerr(sched.Add(yottadb.NOTTP, nil, "hourly", time.Hour, start), t)
+	Assertnoerr(sched.Add(yottadb.NOTTP, nil, "daily", 24*time.Hour, start.Add(time.Hour)), t)
+	Assertnoerr(sched.Jobs(yottadb.NOTTP, nil, func(job scheduler.Job) bool {
+		jobs = append(jobs, job)
+		return true
+	}), t)
+	assert.Equal(t, []scheduler.Job{{Name: "daily", Interval: 24 * time.Hour, Next: start.Add(time.Hour)},
+		{Name: "hourly", Interval: time.Hour, Next: start}}, jobs)
+	// Nothing is due before the first run
+	run, err := sched.Claim(yottadb.NOTTP, nil, start.Add(-time.Second))
+	Assertnoerr(err, t)
+	assert.Nil(t, run)
+	// A due job is claimed once, with missed runs skipped
+	now := start.Add(150 * time.Minute)
+	run, err = sched.Claim(yottadb.NOTTP, nil, now)
+	Assertnoerr(err, t)
+	assert.Equal(t, "daily", run.Name)
+	assert.True(t, start.Add(time.Hour).Equal(run.Scheduled))
+	run2, err := sched.Claim(yottadb.NOTTP, nil, now)
+	Assertnoerr(err, t)
+	assert.Equal(t, "hourly", run2.Name)
+	run3, err := sched.Claim(yottadb.NOTTP, nil, now)
+	Assertnoerr(err, t)
+	assert.Nil(t, run3)
+	// A job running in this process is not claimed again, even when it is due, though the process holds its lock
+	Assertnoerr(sched.Add(yottadb.NOTTP, nil, "daily", 24*time.Hour, now), t)
+	run3, err = sched.Claim(yottadb.NOTTP, nil, now)
+	Assertnoerr(err, t)
+	assert.Nil(t, run3)
+	Assertnoerr(sched.Add(yottadb.NOTTP, nil, "daily", 24*time.Hour, start.Add(25*time.Hour)), t)
+	Assertnoerr(run.Done(yottadb.NOTTP, nil), t)
+	Assertnoerr(run2.Done(yottadb.NOTTP, nil), t)
+	jobs = nil
+	Assertnoerr(sched.Jobs(yottadb.NOTTP, nil, func(job scheduler.Job) bool {
+		jobs = append(jobs, job)
+		return true
+	}), t)
+	assert.True(t, start.Add(25*time.Hour).Equal(jobs[0].Next))
+	assert.True(t, start.Add(3*time.Hour).Equal(jobs[1].Next))
+	assert.False(t, jobs[1].Last.IsZero())
+	// A removed job is no longer claimed
+	Assertnoerr(sched.Remove(yottadb.NOTTP, nil, "hourly"), t)
+	run, err = sched.Claim(yottadb.NOTTP, nil, start.Add(100*time.Hour))
+	Assertnoerr(err, t)
+	assert.Equal(t, "daily", run.Name)
+	Assertnoerr(run.Done(yottadb.NOTTP, nil), t)
+	run, err = sched.Claim(yottadb.NOTTP, nil, start.Add(100*time.Hour))
+	Assertnoerr(err, t)
+	assert.Nil(t, run)
+	assert.Panics(t, func() { sched.Add(yottadb.NOTTP, nil, "never", 0, start) })
+}
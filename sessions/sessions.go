@@ -0,0 +1,184 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package sessions stores the sessions of web applications in YottaDB, with expiry, so the application servers sharing a
+database share their sessions.
+
+A Store implements the store interface of the github.com/alexedwards/scs session manager (Find(), Commit() and Delete()),
+and can be used directly by other session managers. A session is stored in ^sessions(token,...) nodes as its encoded data
+and its expiry time, with times in nanoseconds since the Unix epoch. A session manager sets the absolute expiry of a
+session when it commits the session. If the IdleTimeout of the Store is set, a session also expires when it is not found
+for that long: each Find() slides its expiry forward, up to its absolute expiry.
+
+For example, with scs:
+
+	manager := scs.New()
+	manager.Store = sessions.New()
+	http.ListenAndServe(":8080", manager.LoadAndSave(mux))
+
+Expired sessions are not returned but are only deleted when they are next looked up, so a long running application should
+call DeleteExpired() periodically. A Store accesses the database with NOTTP as the interface has no way to pass a tptoken,
+so it cannot be used within a transaction. The data of a session must fit in the value of a node (1MiB).
+*/
+package sessions
+
+import (
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"strconv"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() stores sessions in
+const DefaultGlobal string = "^sessions"
+
+// Store is a session store keeping sessions in Global(token,...) nodes
+type Store struct {
+	Global      string        // Global variable name holding sessions, e.g. "^sessions"
+	IdleTimeout time.Duration // Time after which a session that is not found expires, or zero for no idle expiry
+}
+
+// session is a structure holding a session as stored in the database
+type session struct {
+	data     string    // Encoded data of the session
+	expiry   time.Time // Time the session expires
+	deadline time.Time // Absolute expiry set by Commit(), which Find() does not extend expiry past
+	found    bool      // Whether the session exists and has not expired
+}
+
+// New is a function to return the session store stored in DefaultGlobal, without an idle timeout
+func New() *Store {
+	return &Store{Global: DefaultGlobal}
+}
+
+// Find is a method to return the data of the session with the given token, and whether the session was found. An expired
+// session is deleted and reported as not found. If the store has an idle timeout, Find() extends the expiry of the session.
+func (store *Store) Find(token string) ([]byte, bool, error) {
+	sess, err := yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (session, error) {
+		now := time.Now()
+		sess, err := store.read(tptoken, errstr, token, true)
+		if nil != err || !sess.found {
+			return sess, err
+		}
+		if !now.Before(sess.expiry) {
+			sess.found = false
+			return sess, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, store.Global, []string{token})
+		}
+		if 0 < store.IdleTimeout {
+			expiry := store.expiry(now, sess.deadline)
+			if expiry.After(sess.expiry) {
+				err = store.setTime(tptoken, errstr, token, "expiry", expiry)
+			}
+		}
+		return sess, err
+	}, "", []string{})
+	if nil != err || !sess.found {
+		return nil, false, err
+	}
+	return []byte(sess.data), true, nil
+}
+
+// Commit is a method to add or replace the session with the given token, with the given data and absolute expiry time
+func (store *Store) Commit(token string, b []byte, expiry time.Time) error {
+	_, err := yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		if err := yottadb.SetValE(tptoken, errstr, string(b), store.Global, []string{token, "data"}); nil != err {
+			return struct{}{}, err
+		}
+		if err := store.setTime(tptoken, errstr, token, "deadline", expiry); nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, store.setTime(tptoken, errstr, token, "expiry", store.expiry(time.Now(), expiry))
+	}, "", []string{})
+	return err
+}
+
+// Delete is a method to delete the session with the given token. Deleting a session that does not exist is not an error.
+func (store *Store) Delete(token string) error {
+	return yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, store.Global, []string{token})
+}
+
+// DeleteExpired is a method to delete every expired session, and return the number of sessions deleted
+func (store *Store) DeleteExpired() (int, error) {
+	var deleted int
+	var delerr error
+
+	err := yottadb.SubRangeE(yottadb.NOTTP, nil, store.Global, []string{}, "", "", func(token string) bool {
+		var expired bool
+
+		expired, delerr = yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (bool, error) {
+			sess, err := store.read(tptoken, errstr, token, false)
+			if nil != err || (sess.found && time.Now().Before(sess.expiry)) {
+				return false, err
+			}
+			return true, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, store.Global, []string{token})
+		}, "", []string{})
+		if expired {
+			deleted++
+		}
+		return nil == delerr
+	})
+	if nil != delerr {
+		return deleted, delerr
+	}
+	return deleted, err
+}
+
+// expiry is a method to return the expiry of a session used at now with the given absolute expiry
+func (store *Store) expiry(now, deadline time.Time) time.Time {
+	if 0 < store.IdleTimeout && now.Add(store.IdleTimeout).Before(deadline) {
+		return now.Add(store.IdleTimeout)
+	}
+	return deadline
+}
+
+// read is a method to read the session with the given token, and its data if withData is true. A session with no expiry
+// is reported as found with the zero expiry time, so that it is treated as expired.
+func (store *Store) read(tptoken uint64, errstr *yottadb.BufferT, token string, withData bool) (session, error) {
+	var sess session
+
+	value, err := yottadb.ValE(tptoken, errstr, store.Global, []string{token, "expiry"})
+	if nil != err {
+		if yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err) {
+			data, err := yottadb.DataE(tptoken, errstr, store.Global, []string{token})
+			sess.found = 0 != data
+			return sess, err
+		}
+		return sess, err
+	}
+	sess.found = true
+	if sess.expiry, err = parseTime(token, "expiry", value); nil != err {
+		return sess, err
+	}
+	if value, err = yottadb.ValE(tptoken, errstr, store.Global, []string{token, "deadline"}); nil != err {
+		return sess, err
+	}
+	if sess.deadline, err = parseTime(token, "deadline", value); nil != err || !withData {
+		return sess, err
+	}
+	sess.data, err = yottadb.ValE(tptoken, errstr, store.Global, []string{token, "data"})
+	return sess, err
+}
+
+// setTime is a method to set a time of the session with the given token
+func (store *Store) setTime(tptoken uint64, errstr *yottadb.BufferT, token, field string, t time.Time) error {
+	return yottadb.SetValE(tptoken, errstr, strconv.FormatInt(t.UnixNano(), 10), store.Global, []string{token, field})
+}
+
+// parseTime is a function to parse a time of the session with the given token
+func parseTime(token, field, value string) (time.Time, error) {
+	nsec, err := strconv.ParseInt(value, 10, 64)
+	if nil != err {
+		return time.Time{}, fmt.Errorf("sessions: invalid %s time %q for session %s", field, value, token)
+	}
+	return time.Unix(0, nsec), nil
+}
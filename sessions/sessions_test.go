@@ -0,0 +1,77 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package sessions_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/sessions"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestStore(t *testing.T) {
+	store := sessions.New()
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, store.Global, []string{})
+	_, found, err := store.Find("none")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	Assertnoerr(store.Commit("tok1", []byte("data1"), time.Now().Add(time.Hour)), t)
+	Assertnoerr(store.Commit("tok2", []byte{0, 1, 2}, time.Now().Add(-time.Second)), t)
+	data, found, err := store.Find("tok1")
+	Assertnoerr(err, t)
+	assert.True(t, found)
+	assert.Equal(t, []byte("data1"), data)
+	// An expired session is not found
+	_, found, err = store.Find("tok2")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	Assertnoerr(store.Delete("tok1"), t)
+	_, found, err = store.Find("tok1")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	Assertnoerr(store.Delete("tok1"), t)
+}
+
+func TestStoreIdleTimeout(t *testing.T) {
+	store := sessions.New()
+	store.IdleTimeout = 200 * time.Millisecond
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, store.Global, []string{})
+	Assertnoerr(store.Commit("idle", []byte("x"), time.Now().Add(time.Hour)), t)
+	Assertnoerr(store.Commit("used", []byte("y"), time.Now().Add(time.Hour)), t)
+	Assertnoerr(store.Commit("short", []byte("z"), time.Now().Add(time.Hour)), t)
+	// Finding a session slides its expiry forward
+	for i := 0; i < 3; i++ {
+		time.Sleep(100 * time.Millisecond)
+		_, found, err := store.Find("used")
+		Assertnoerr(err, t)
+		assert.True(t, found)
+	}
+	_, found, err := store.Find("idle")
+	Assertnoerr(err, t)
+	assert.False(t, found)
+	// Sessions not found since the idle timeout are deleted by DeleteExpired()
+	time.Sleep(250 * time.Millisecond)
+	deleted, err := store.DeleteExpired()
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, deleted)
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, store.Global, []string{})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
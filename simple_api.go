@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -52,6 +52,9 @@ func LockST(tptoken uint64, errstr *BufferT, timeoutNsec uint64, lockname ...*Ke
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return err
+	}
 	defer vplist.free()
 	vplist.alloc()
 	// First two parms are the tptoken and the contents of the errstr BufferT (not the BufferT itself).
@@ -96,7 +99,7 @@ func LockST(tptoken uint64, errstr *BufferT, timeoutNsec uint64, lockname ...*Ke
 				errmsg = strings.Replace(errmsg, "!AD", "LockST()", 1)
 				errmsg = strings.Replace(errmsg, "!UL", fmt.Sprintf("%d", namecnt), 1)
 				errmsg = strings.Replace(errmsg, "!UL", fmt.Sprintf("%d", parmsleftorig/3), 1)
-				return &YDBError{(int)(YDB_ERR_NAMECOUNT2HI), errmsg}
+				return &YDBError{errcode: (int)(YDB_ERR_NAMECOUNT2HI), errmsg: errmsg}
 			}
 			// Set the 3 parameters for this lockname
 			err = vplist.setVPlistParam(tptoken, errstr,
@@ -135,7 +138,7 @@ func LockST(tptoken uint64, errstr *BufferT, timeoutNsec uint64, lockname ...*Ke
 	// top of this routine) to do the call that callVariadicPlistFuncST() would have done.
 	rc := vplist.callVariadicPlistFunc(C.ydb_get_lockst_funcvp()) // Drive ydb_lock_st()
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "LockST")
 		return err
 	}
 	runtime.KeepAlive(lockname) // Make sure these structures hangs around through the YDB call
@@ -0,0 +1,115 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"time"
+)
+
+// SoftDeleteSubscript is the subscript of the child node that marks a node as soft deleted. It starts with $CHAR(0) so it
+// does not clash with the subscripts of application data.
+const SoftDeleteSubscript = "\x00deleted"
+
+// SoftDeleteFilter selects the nodes visited by SubRangeSoftE() by whether they are soft deleted
+type SoftDeleteFilter int
+
+// Filters of soft deleted nodes for SubRangeSoftE()
+const (
+	ExcludeDeleted SoftDeleteFilter = iota // Visit only the nodes that are not soft deleted
+	IncludeDeleted                         // Visit all nodes
+	OnlyDeleted                            // Visit only the nodes that are soft deleted
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API functions for soft deletion
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SoftDeleteE is an Easy API function to mark varname(subary...) as deleted without removing its value or descendants, so
+// that it can be restored by UndeleteE() or purged later with DeleteE().
+//
+// The mark is the child node varname(subary...,SoftDeleteSubscript), which SoftDeleteE() sets to the UTC time of the
+// deletion in RFC 3339 format. Soft deleting a node that is already soft deleted keeps its original time. Applications
+// reading soft deleted data should check IsDeletedE() or iterate with SubRangeSoftE(), which skips the mark.
+func SoftDeleteE(tptoken uint64, errstr *BufferT, varname string, subary []string) error {
+	var marksubs []string
+
+	printEntry("SoftDeleteE()")
+	marksubs = append(append(marksubs, subary...), SoftDeleteSubscript)
+	_, err := TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (struct{}, error) {
+		data, err := DataE(tptoken, errstr, varname, marksubs)
+		if nil != err || 0 != data {
+			return struct{}{}, err
+		}
+		return struct{}{}, SetValE(tptoken, errstr, time.Now().UTC().Format(time.RFC3339), varname, marksubs)
+	}, "", []string{})
+	return err
+}
+
+// UndeleteE is an Easy API function to remove the mark set by SoftDeleteE() on varname(subary...). It does nothing if the
+// node is not soft deleted.
+func UndeleteE(tptoken uint64, errstr *BufferT, varname string, subary []string) error {
+	var marksubs []string
+
+	printEntry("UndeleteE()")
+	marksubs = append(append(marksubs, subary...), SoftDeleteSubscript)
+	return DeleteE(tptoken, errstr, YDB_DEL_NODE, varname, marksubs)
+}
+
+// IsDeletedE is an Easy API function to return whether varname(subary...) is marked as deleted by SoftDeleteE()
+func IsDeletedE(tptoken uint64, errstr *BufferT, varname string, subary []string) (bool, error) {
+	var marksubs []string
+
+	printEntry("IsDeletedE()")
+	marksubs = append(append(marksubs, subary...), SoftDeleteSubscript)
+	data, err := DataE(tptoken, errstr, varname, marksubs)
+	if nil != err {
+		return false, err
+	}
+	return 0 != data, nil
+}
+
+// SubRangeSoftE is an Easy API function to call fn for each subscript at the next level of varname(subary...) as
+// SubRangeE() does, skipping the soft deletion mark SoftDeleteSubscript and visiting only the nodes selected by filter.
+// Iteration stops early if fn returns false.
+//
+// If a database call returns an error, iteration stops and the function returns the error.
+func SubRangeSoftE(tptoken uint64, errstr *BufferT, varname string, subary []string, from, to string,
+	filter SoftDeleteFilter, fn func(sub string) bool) error {
+	var dbsubs []string
+	var deleted bool
+	var delerr error
+
+	printEntry("SubRangeSoftE()")
+	dbsubs = append(append(dbsubs, subary...), "")
+	err := SubRangeE(tptoken, errstr, varname, subary, from, to, func(sub string) bool {
+		if SoftDeleteSubscript == sub {
+			return true
+		}
+		if IncludeDeleted != filter {
+			dbsubs[len(dbsubs)-1] = sub
+			if deleted, delerr = IsDeletedE(tptoken, errstr, varname, dbsubs); nil != delerr {
+				return false
+			}
+			if deleted != (OnlyDeleted == filter) {
+				return true
+			}
+		}
+		return fn(sub)
+	})
+	if nil != err {
+		return err
+	}
+	return delerr
+}
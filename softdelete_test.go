@@ -0,0 +1,57 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestSoftDeleteE(t *testing.T) {
+	for _, sub := range []string{"a", "b", "c"} {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, sub, "^softdelete", []string{sub}), t)
+	}
+	Assertnoerr(yottadb.SoftDeleteE(yottadb.NOTTP, nil, "^softdelete", []string{"b"}), t)
+	Assertnoerr(yottadb.SoftDeleteE(yottadb.NOTTP, nil, "^softdelete", []string{}), t)
+	deleted, err := yottadb.IsDeletedE(yottadb.NOTTP, nil, "^softdelete", []string{"b"})
+	Assertnoerr(err, t)
+	assert.True(t, deleted)
+	deleted, err = yottadb.IsDeletedE(yottadb.NOTTP, nil, "^softdelete", []string{"a"})
+	Assertnoerr(err, t)
+	assert.False(t, deleted)
+	// The value of a soft deleted node is kept
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^softdelete", []string{"b"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "b", val)
+
+	visit := func(filter yottadb.SoftDeleteFilter) []string {
+		subs := []string{}
+		Assertnoerr(yottadb.SubRangeSoftE(yottadb.NOTTP, nil, "^softdelete", []string{}, "", "", filter, func(sub string) bool {
+			subs = append(subs, sub)
+			return true
+		}), t)
+		return subs
+	}
+	assert.Equal(t, []string{"a", "c"}, visit(yottadb.ExcludeDeleted))
+	assert.Equal(t, []string{"a", "b", "c"}, visit(yottadb.IncludeDeleted))
+	assert.Equal(t, []string{"b"}, visit(yottadb.OnlyDeleted))
+
+	Assertnoerr(yottadb.UndeleteE(yottadb.NOTTP, nil, "^softdelete", []string{"b"}), t)
+	Assertnoerr(yottadb.UndeleteE(yottadb.NOTTP, nil, "^softdelete", []string{"b"}), t)
+	assert.Equal(t, []string{"a", "b", "c"}, visit(yottadb.ExcludeDeleted))
+	deleted, err = yottadb.IsDeletedE(yottadb.NOTTP, nil, "^softdelete", []string{})
+	Assertnoerr(err, t)
+	assert.True(t, deleted)
+}
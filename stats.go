@@ -0,0 +1,152 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"math/bits"
+	"sync/atomic"
+	"time"
+)
+
+// Types of operations timed when statistics are enabled with EnableStats()
+const (
+	statGet  int = iota // Fetching values (ValE(), KeyT.ValST())
+	statSet             // Setting values (SetValE(), KeyT.SetValST())
+	statKill            // Deleting nodes and trees (DeleteE(), DeleteExclE(), KeyT.DeleteST(), BufferTArray.DeleteExclST())
+	statNext            // Traversing subscripts and nodes (SubNextE(), NodePrevE(), KeyT.SubNextST() etc.)
+	statTxn             // Transactions, including their closures (TpE(), TpValE(), BufferTArray.TpST())
+	statOpCount
+)
+
+// statOpNames are the names of the types of operations in the map returned by Stats()
+var statOpNames = [statOpCount]string{"Get", "Set", "Kill", "Next", "Txn"}
+
+// statBucketCount is the number of histogram buckets - bucket i counts durations of at least 2**i and less than 2**(i+1)
+// nanoseconds (bucket 0 also counts durations of 0)
+const statBucketCount int = 64
+
+// latencyHistogram is a structure recording the durations of one type of operation. All fields are updated atomically.
+type latencyHistogram struct {
+	count   uint64                  // Number of operations
+	sumNsec uint64                  // Total duration of the operations in nanoseconds
+	buckets [statBucketCount]uint64 // Counts of operations by duration (see statBucketCount)
+}
+
+var statHistograms [statOpCount]latencyHistogram // Durations of each type of operation
+
+// OpStats is a structure summarizing the durations of one type of operation, as returned by Stats()
+type OpStats struct {
+	Count   uint64        // Number of operations
+	Total   time.Duration // Total duration of the operations
+	Buckets []uint64      // Buckets[i] is the number of operations that took at least 2**i and less than 2**(i+1) nanoseconds
+}
+
+// EnableStats is a function to start (enable true) or stop (enable false) timing the database operations of the process,
+// for retrieval with Stats(). Durations are recorded for fetches ("Get"), sets ("Set"), deletes ("Kill"), subscript and
+// node traversal ("Next") and transactions ("Txn"). Statistics are recorded in histograms of power-of-two buckets, so
+// recording costs a call to time.Now() and a few atomic additions per operation. When statistics are disabled, the cost
+// is one atomic load per operation. Statistics already recorded are kept when timing stops - see ResetStats().
+func EnableStats(enable bool) {
+	setHook(hookStats, enable)
+}
+
+// ResetStats is a function to discard all recorded statistics.
+func ResetStats() {
+	for op := range statHistograms {
+		hist := &statHistograms[op]
+		atomic.StoreUint64(&hist.count, 0)
+		atomic.StoreUint64(&hist.sumNsec, 0)
+		for i := range hist.buckets {
+			atomic.StoreUint64(&hist.buckets[i], 0)
+		}
+	}
+}
+
+// Stats is a function to return the statistics recorded since EnableStats() was first called (or since ResetStats() was
+// last called), indexed by the type of operation: "Get", "Set", "Kill", "Next" and "Txn". As statistics are updated
+// without locks, a call made while operations run may see an operation in Count but not yet in Total or Buckets.
+func Stats() map[string]OpStats {
+	stats := make(map[string]OpStats, statOpCount)
+	for op := range statHistograms {
+		hist := &statHistograms[op]
+		opstats := OpStats{
+			Count:   atomic.LoadUint64(&hist.count),
+			Total:   time.Duration(atomic.LoadUint64(&hist.sumNsec)),
+			Buckets: make([]uint64, statBucketCount),
+		}
+		for i := range hist.buckets {
+			opstats.Buckets[i] = atomic.LoadUint64(&hist.buckets[i])
+		}
+		stats[statOpNames[op]] = opstats
+	}
+	return stats
+}
+
+// Mean is a method to return the mean duration of the operations, or 0 if there were none.
+func (opstats OpStats) Mean() time.Duration {
+	if 0 == opstats.Count {
+		return 0
+	}
+	return opstats.Total / time.Duration(opstats.Count)
+}
+
+// Quantile is a method to return an upper bound of the duration that the given fraction q (0 to 1, e.g. 0.99 for the
+// 99th percentile) of the operations took at most. As durations are recorded in power-of-two buckets, the bound is the upper
+// limit of the bucket holding the quantile, which is less than twice the actual quantile. Zero is returned if there were
+// no operations.
+func (opstats OpStats) Quantile(q float64) time.Duration {
+	var total, seen uint64
+
+	for _, count := range opstats.Buckets {
+		total += count
+	}
+	if 0 == total {
+		return 0
+	}
+	rank := uint64(q * float64(total))
+	if rank >= total {
+		rank = total - 1
+	}
+	for i, count := range opstats.Buckets {
+		seen += count
+		if seen > rank {
+			if statBucketCount-1 <= i {
+				return time.Duration(1<<63 - 1)
+			}
+			return time.Duration(uint64(1) << (i + 1))
+		}
+	}
+	return 0
+}
+
+// recordStat is a function to record the duration of an operation of the given type that started at start. It is called
+// (deferred) by the timed functions that are not instrumented by beginOp() when statistics are enabled.
+func recordStat(op int, start time.Time) {
+	recordDuration(op, time.Since(start))
+}
+
+// recordDuration is a function to record the duration of an operation of the given type
+func recordDuration(op int, elapsed time.Duration) {
+	nsec := uint64(0)
+	if 0 < elapsed {
+		nsec = uint64(elapsed)
+	}
+	hist := &statHistograms[op]
+	atomic.AddUint64(&hist.count, 1)
+	atomic.AddUint64(&hist.sumNsec, nsec)
+	bucket := 0
+	if 0 < nsec {
+		bucket = bits.Len64(nsec) - 1
+	}
+	atomic.AddUint64(&hist.buckets[bucket], 1)
+}
@@ -0,0 +1,60 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+	"time"
+)
+
+func TestStats(t *testing.T) {
+	yottadb.ResetStats()
+	yottadb.EnableStats(true)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "1", "^stats", []string{"a"}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "2", "^stats", []string{"b"}), t)
+	_, err := yottadb.ValE(yottadb.NOTTP, nil, "^stats", []string{"a"})
+	Assertnoerr(err, t)
+	_, err = yottadb.SubNextE(yottadb.NOTTP, nil, "^stats", []string{"a"})
+	Assertnoerr(err, t)
+	Assertnoerr(yottadb.TpE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		return yottadb.YDB_OK
+	}, "BATCH", []string{}), t)
+	Assertnoerr(yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^stats", []string{}), t)
+	yottadb.EnableStats(false)
+	// Operations are not timed once statistics are disabled
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "3", "^stats", []string{"c"}), t)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^stats", []string{})
+	stats := yottadb.Stats()
+	for op, count := range map[string]uint64{"Get": 1, "Set": 2, "Kill": 1, "Next": 1, "Txn": 1} {
+		assert.Equal(t, count, stats[op].Count, op)
+		var buckets uint64
+		for _, n := range stats[op].Buckets {
+			buckets += n
+		}
+		assert.Equal(t, count, buckets, op)
+		assert.True(t, 0 < stats[op].Mean() && stats[op].Mean() <= stats[op].Quantile(1), op)
+	}
+	yottadb.ResetStats()
+	assert.Equal(t, uint64(0), yottadb.Stats()["Set"].Count)
+	// Quantiles are the upper bounds of power-of-two buckets
+	opstats := yottadb.OpStats{Count: 4, Total: 40, Buckets: make([]uint64, 64)}
+	opstats.Buckets[3] = 3 // 8-15ns
+	opstats.Buckets[4] = 1 // 16-31ns
+	assert.Equal(t, time.Duration(10), opstats.Mean())
+	assert.Equal(t, time.Duration(16), opstats.Quantile(0.5))
+	assert.Equal(t, time.Duration(32), opstats.Quantile(0.99))
+	assert.Equal(t, time.Duration(0), yottadb.OpStats{}.Quantile(0.5))
+}
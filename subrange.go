@@ -0,0 +1,171 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"context"
+)
+
+// TreeNode is a structure holding a node of a tree sent by TreeChan()
+type TreeNode struct {
+	Subary []string // Subscripts of the node
+	Value  string   // Value of the node
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API function to iterate over a range of subscripts
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SubRangeE is an Easy API function to call fn for each subscript at the next level of varname(subary...) that collates at
+// or after from and before to, in collation order. An empty from starts with the first subscript and an empty to continues
+// to the last subscript. Iteration stops early if fn returns false. For example, with timestamp subscripts such as
+// "2024-01-01T09:30", a from of "2024-01-01" and a to of "2024-01-02" scan exactly the subscripts of January 1, 2024.
+//
+// Order is determined by CompareSubscripts(), which matches the M standard collation used by YottaDB unless an alternate
+// collation is in effect for the variable. SubRangeE() wraps SubNextE() and fn may update the database (including the node
+// at the subscript passed to it) as iteration continues from the last subscript passed to fn.
+//
+// If a database call returns an error, iteration stops and the function returns the error.
+func SubRangeE(tptoken uint64, errstr *BufferT, varname string, subary []string, from, to string,
+	fn func(sub string) bool) error {
+	var dbsubs []string
+
+	printEntry("SubRangeE()")
+	dbsubs = append(append(dbsubs, subary...), from)
+	if "" != from {
+		// The from subscript itself is part of the range if it exists
+		data, err := DataE(tptoken, errstr, varname, dbsubs)
+		if nil != err {
+			return err
+		}
+		if 0 != data && ("" == to || 0 > CompareSubscripts(from, to)) && !fn(from) {
+			return nil
+		}
+	}
+	for {
+		next, err := SubNextE(tptoken, errstr, varname, dbsubs)
+		if nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		if "" != to && 0 <= CompareSubscripts(next, to) {
+			return nil
+		}
+		if !fn(next) {
+			return nil
+		}
+		dbsubs[len(dbsubs)-1] = next
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Adapters of iteration to slices and channels
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SubSliceE is an Easy API function to return the subscripts at the next level of varname(subary...) in collation order,
+// as SubRangeE() visits them from the first to the last. At most limit subscripts are returned, or all of them if limit is
+// not positive.
+func SubSliceE(tptoken uint64, errstr *BufferT, varname string, subary []string, limit int) ([]string, error) {
+	subs := []string{}
+
+	printEntry("SubSliceE()")
+	err := SubRangeE(tptoken, errstr, varname, subary, "", "", func(sub string) bool {
+		subs = append(subs, sub)
+		return 0 >= limit || len(subs) < limit
+	})
+	if nil != err {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// TreeChan is a function to send each node with a value in the tree of varname(subary...), including varname(subary...)
+// itself, to the returned node channel in the order NodeNextE() visits them, for consumers that use channels (e.g. in
+// pipelines of goroutines or with select). The node channel has the given buffer size and is closed when the traversal
+// ends. If a database call returns an error, the traversal ends and the error is sent to the returned error channel, which
+// is closed after the node channel. Cancelling ctx also ends the traversal, with ctx.Err() sent as the error.
+//
+// The traversal runs in its own goroutine with NOTTP, so it cannot be part of a transaction, and sees the updates of other
+// processes and goroutines made as it runs. Callers that stop receiving before the node channel is closed must cancel ctx
+// so the goroutine ends.
+func TreeChan(ctx context.Context, varname string, subary []string, buffer int) (<-chan TreeNode, <-chan error) {
+	nodes := make(chan TreeNode, buffer)
+	errs := make(chan error, 1)
+	root := append([]string{}, subary...)
+
+	printEntry("TreeChan()")
+	go func() {
+		defer close(errs)
+		defer close(nodes)
+		if err := sendTree(ctx, nodes, varname, root); nil != err {
+			errs <- err
+		}
+	}()
+	return nodes, errs
+}
+
+// sendTree is a function to send the nodes of the tree of varname(root...) for TreeChan().
+func sendTree(ctx context.Context, nodes chan<- TreeNode, varname string, root []string) error {
+	data, err := DataE(NOTTP, nil, varname, root)
+	if nil != err {
+		return err
+	}
+	if 0 == data {
+		return nil
+	}
+	subary := root
+	if 1 == data%10 {
+		if err = sendTreeNode(ctx, nodes, varname, subary); nil != err {
+			return err
+		}
+	}
+	for {
+		subary, err = NodeNextE(NOTTP, nil, varname, subary)
+		if nil != err {
+			if YDB_ERR_NODEEND == ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		if len(subary) <= len(root) || !equalSubscripts(subary[:len(root)], root) {
+			return nil // Past the last descendant of the root
+		}
+		if err = sendTreeNode(ctx, nodes, varname, subary); nil != err {
+			return err
+		}
+	}
+}
+
+// sendTreeNode is a function to fetch the value of varname(subary...) and send the node for TreeChan(), skipping a node
+// deleted since it was found.
+func sendTreeNode(ctx context.Context, nodes chan<- TreeNode, varname string, subary []string) error {
+	value, err := ValE(NOTTP, nil, varname, subary)
+	if nil != err {
+		if YDB_ERR_GVUNDEF == ErrorCode(err) || YDB_ERR_LVUNDEF == ErrorCode(err) {
+			return nil
+		}
+		return err
+	}
+	select {
+	case nodes <- TreeNode{Subary: append([]string{}, subary...), Value: value}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,103 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"strings"
+	"testing"
+)
+
+func TestSubRangeE(t *testing.T) {
+	var errstr yottadb.BufferT
+	var subs []string
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	for _, sub := range []string{"1", "5", "10", "2024-01-01T09:30", "2024-01-01T12:00", "2024-01-02", "a", "b", "c"} {
+		err := yottadb.SetValE(yottadb.NOTTP, &errstr, "", "^subrange", []string{"x", sub})
+		Assertnoerr(err, t)
+	}
+	collect := func(from, to string) []string {
+		subs = nil
+		err := yottadb.SubRangeE(yottadb.NOTTP, &errstr, "^subrange", []string{"x"}, from, to, func(sub string) bool {
+			subs = append(subs, sub)
+			return true
+		})
+		Assertnoerr(err, t)
+		return subs
+	}
+	// Numeric subscripts collate numerically so 5 is before 10, and before all strings
+	assert.Equal(t, []string{"5", "10"}, collect("2", "11"))
+	assert.Equal(t, []string{"10", "2024-01-01T09:30"}, collect("6", "2024-01-01T10"))
+	// from is inclusive when it exists and to is exclusive
+	assert.Equal(t, []string{"5", "10"}, collect("5", "2024-01-01T09:30"))
+	assert.Equal(t, []string{"2024-01-01T09:30", "2024-01-01T12:00"}, collect("2024-01-01", "2024-01-02"))
+	assert.Equal(t, []string{"a", "b"}, collect("a", "c"))
+	// Empty bounds are the ends of the subscripts
+	assert.Equal(t, []string{"1", "5"}, collect("", "10"))
+	assert.Equal(t, []string{"b", "c"}, collect("b", ""))
+	assert.Equal(t, 9, len(collect("", "")))
+	assert.Empty(t, collect("c", "a"))
+	// Iteration stops when fn returns false
+	count := 0
+	err := yottadb.SubRangeE(yottadb.NOTTP, &errstr, "^subrange", []string{"x"}, "", "", func(sub string) bool {
+		count++
+		return 3 > count
+	})
+	Assertnoerr(err, t)
+	assert.Equal(t, 3, count)
+	// Errors are returned
+	err = yottadb.SubRangeE(yottadb.NOTTP, &errstr, "^", []string{}, "a", "", func(sub string) bool { return true })
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+}
+
+func TestSubSliceEAndTreeChan(t *testing.T) {
+	var errstr yottadb.BufferT
+	var nodes []yottadb.TreeNode
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^subslice", []string{})
+	for _, subary := range [][]string{{"x"}, {"x", "10"}, {"x", "2"}, {"x", "2", "a"}, {"y"}} {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, strings.Join(subary, "-"), "^subslice", subary), t)
+	}
+	subs, err := yottadb.SubSliceE(yottadb.NOTTP, &errstr, "^subslice", []string{"x"}, 0)
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"2", "10"}, subs)
+	subs, err = yottadb.SubSliceE(yottadb.NOTTP, &errstr, "^subslice", []string{}, 1)
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"x"}, subs)
+	subs, err = yottadb.SubSliceE(yottadb.NOTTP, &errstr, "^subslice", []string{"y"}, 0)
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{}, subs)
+	// The tree of ^subslice("x") includes the node itself but not its sibling ^subslice("y")
+	nodech, errch := yottadb.TreeChan(context.Background(), "^subslice", []string{"x"}, 2)
+	for node := range nodech {
+		nodes = append(nodes, node)
+	}
+	Assertnoerr(<-errch, t)
+	assert.Equal(t, []yottadb.TreeNode{{Subary: []string{"x"}, Value: "x"}, {Subary: []string{"x", "2"}, Value: "x-2"},
+		{Subary: []string{"x", "2", "a"}, Value: "x-2-a"}, {Subary: []string{"x", "10"}, Value: "x-10"}}, nodes)
+	// Cancelling the context ends the traversal with its error
+	ctx, cancel := context.WithCancel(context.Background())
+	nodech, errch = yottadb.TreeChan(ctx, "^subslice", []string{}, 0)
+	<-nodech
+	cancel()
+	assert.Equal(t, context.Canceled, <-errch) // Not receiving from the unbuffered node channel leaves only ctx ready
+	_, open := <-nodech
+	assert.False(t, open)
+}
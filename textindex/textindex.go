@@ -0,0 +1,172 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package textindex maintains a word index of the values of nodes in YottaDB, for basic searches over stored text without an
+external search engine.
+
+Values are set through an Index, which splits them into words (see Tokenize()) and records cross references in
+^textindex nodes (or in another global variable of the application's choosing):
+
+	^textindex("w",word,node)    node has word in its value
+	^textindex("n",node,word)    the same, by node - so the words of a node can be removed when its value changes
+
+where node is the name of the node in ZWRITE format, e.g. ^doc(1,"title"). Each value is set and indexed in a single
+transaction, so the index is always consistent with the values.
+
+For example:
+
+	index := textindex.New()
+	err := index.Set(yottadb.NOTTP, nil, "The quick brown fox", "^doc", []string{"1", "title"})
+	...
+	err = index.Search(yottadb.NOTTP, nil, "brown fox", func(node string) bool {
+		fmt.Println(node) // ^doc(1,"title")
+		return true
+	})
+*/
+package textindex
+
+import (
+	"lang.yottadb.com/go/yottadb"
+	"strings"
+	"unicode"
+)
+
+// DefaultGlobal is the global variable New() stores the index in
+const DefaultGlobal string = "^textindex"
+
+// Index is a word index of node values kept in Global("w",...) and Global("n",...) nodes
+type Index struct {
+	Global string // Global (or local) variable name holding the index, e.g. "^textindex"
+}
+
+// New is a function to return the index stored in DefaultGlobal
+func New() *Index {
+	return &Index{Global: DefaultGlobal}
+}
+
+// Tokenize is a function to return the distinct words of the given text in order of first appearance. A word is a run of
+// letters and digits, converted to lower case.
+func Tokenize(text string) []string {
+	var words []string
+
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		word = strings.ToLower(word)
+		if !seen[word] {
+			seen[word] = true
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Set is a method to set the value of varname(subary...), as yottadb.SetValE() does, and replace the words indexed for
+// the node with the words of the new value.
+func (index *Index) Set(tptoken uint64, errstr *yottadb.BufferT, value, varname string, subary []string) error {
+	node := yottadb.ZwriteName(varname, subary)
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		err := yottadb.SetValE(tptoken, errstr, value, varname, subary)
+		if nil != err {
+			return struct{}{}, err
+		}
+		err = index.unindex(tptoken, errstr, node)
+		if nil != err {
+			return struct{}{}, err
+		}
+		for _, word := range Tokenize(value) {
+			err = yottadb.SetValE(tptoken, errstr, "", index.Global, []string{"w", word, node})
+			if nil == err {
+				err = yottadb.SetValE(tptoken, errstr, "", index.Global, []string{"n", node, word})
+			}
+			if nil != err {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	}, "", []string{})
+	return err
+}
+
+// Delete is a method to delete the value of varname(subary...) (its descendants are left alone) and the words indexed
+// for it.
+func (index *Index) Delete(tptoken uint64, errstr *yottadb.BufferT, varname string, subary []string) error {
+	node := yottadb.ZwriteName(varname, subary)
+	_, err := yottadb.TpValE(tptoken, errstr, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		err := yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_NODE, varname, subary)
+		if nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, index.unindex(tptoken, errstr, node)
+	}, "", []string{})
+	return err
+}
+
+// Search is a method to call fn with the name (see yottadb.ZwriteName()) of each node whose value contains all the words of
+// the query, in collation order of the names. Iteration stops early if fn returns false. A query without words matches no
+// nodes.
+//
+// If a database call returns an error, iteration stops and the method returns the error.
+func (index *Index) Search(tptoken uint64, errstr *yottadb.BufferT, query string, fn func(node string) bool) error {
+	words := Tokenize(query)
+	if 0 == len(words) {
+		return nil
+	}
+	// Scan the nodes of the first word, checking each has the other words
+	subary := []string{"w", words[0], ""}
+	for {
+		node, err := yottadb.SubNextE(tptoken, errstr, index.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		subary[2] = node
+		match := true
+		for _, word := range words[1:] {
+			data, err := yottadb.DataE(tptoken, errstr, index.Global, []string{"n", node, word})
+			if nil != err {
+				return err
+			}
+			if 0 == data {
+				match = false
+				break
+			}
+		}
+		if match && !fn(node) {
+			return nil
+		}
+	}
+}
+
+// unindex is a method to remove the words indexed for the given node.
+func (index *Index) unindex(tptoken uint64, errstr *yottadb.BufferT, node string) error {
+	subary := []string{"n", node, ""}
+	for {
+		word, err := yottadb.SubNextE(tptoken, errstr, index.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				break
+			}
+			return err
+		}
+		subary[2] = word
+		err = yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_NODE, index.Global, []string{"w", word, node})
+		if nil != err {
+			return err
+		}
+	}
+	return yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, index.Global, []string{"n", node})
+}
@@ -0,0 +1,65 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package textindex_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/textindex"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestTokenize(t *testing.T) {
+	assert.Equal(t, []string{"the", "quick", "brown", "fox", "42", "café"},
+		textindex.Tokenize("The quick, brown fox... the FOX (42) café"))
+	assert.Equal(t, []string(nil), textindex.Tokenize(" -- "))
+}
+
+func TestSetSearchDelete(t *testing.T) {
+	search := func(query string) []string {
+		var nodes []string
+
+		Assertnoerr(textindex.New().Search(yottadb.NOTTP, nil, query, func(node string) bool {
+			nodes = append(nodes, node)
+			return true
+		}), t)
+		return nodes
+	}
+	index := textindex.New()
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, index.Global, []string{})
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^textdoc", []string{})
+	Assertnoerr(index.Set(yottadb.NOTTP, nil, "The quick brown fox", "^textdoc", []string{"1"}), t)
+	Assertnoerr(index.Set(yottadb.NOTTP, nil, "A brown dog", "^textdoc", []string{"2"}), t)
+	value, err := yottadb.ValE(yottadb.NOTTP, nil, "^textdoc", []string{"1"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "The quick brown fox", value)
+	assert.Equal(t, []string{"^textdoc(1)", "^textdoc(2)"}, search("Brown"))
+	assert.Equal(t, []string{"^textdoc(1)"}, search("fox brown"))
+	assert.Equal(t, []string(nil), search("brown cat"))
+	assert.Equal(t, []string(nil), search(""))
+	// Changing a value replaces its words
+	Assertnoerr(index.Set(yottadb.NOTTP, nil, "A lazy cat", "^textdoc", []string{"1"}), t)
+	assert.Equal(t, []string{"^textdoc(2)"}, search("brown"))
+	assert.Equal(t, []string{"^textdoc(1)"}, search("cat"))
+	Assertnoerr(index.Delete(yottadb.NOTTP, nil, "^textdoc", []string{"1"}), t)
+	assert.Equal(t, []string(nil), search("cat"))
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, "^textdoc", []string{"1"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
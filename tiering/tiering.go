@@ -0,0 +1,289 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package tiering moves cold subtrees of YottaDB variables to an object store, such as S3, and brings them back when they
+are read, for installations whose history globals grow faster than is worth keeping in the database.
+
+Archiving a node encodes the nodes of its subtree, stores them as one object in the Backend, and replaces the subtree in
+the database with a stub recording the key of the object. Stubs are kept apart from the data, in ^tiering(varname,subs...)
+nodes (or in another global variable of the application's choosing) whose subscripts are the variable name and the
+subscripts of the archived node. Restoring a node loads its subtree back from the object and removes the stub and the
+object. ValE() reads a node through the tiers, restoring the subtree it is in first if that subtree is archived.
+
+For example, with history kept in ^orders(date,...):
+
+	tier := tiering.New(s3Backend)
+	// Archive the orders of each date before 2023
+	n, err := tier.ArchiveBefore(ctx, "^orders", []string{}, "2023-01-01")
+	...
+	// Read an order, restoring its date from the object store if needed
+	status, err := tier.ValE(ctx, "^orders", []string{"2022-06-30", "1234", "status"})
+
+Tiering accesses the database with NOTTP, as backends cannot take part in YottaDB transactions. Archiving checks in a
+transaction that the subtree did not change while it was stored, and leaves a subtree that changed in the database, but
+an application should not update a subtree while it is being archived or restored.
+*/
+package tiering
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"lang.yottadb.com/go/yottadb"
+)
+
+// DefaultGlobal is the global variable New() stores stubs in
+const DefaultGlobal string = "^tiering"
+
+// ErrArchived is returned by Archive() when the node is archived already
+var ErrArchived = errors.New("tiering: node is archived already")
+
+// ErrChanged is returned by Archive() when the subtree changed while it was stored in the backend
+var ErrChanged = errors.New("tiering: subtree changed while it was archived")
+
+// Backend is the interface of the object store holding archived subtrees, implemented by the application for its store
+type Backend interface {
+	Put(ctx context.Context, key string, data []byte) error // Store data as the object with the given key
+	Get(ctx context.Context, key string) ([]byte, error)    // Return the data of the object with the given key
+	Delete(ctx context.Context, key string) error           // Delete the object with the given key
+}
+
+// Tier is a set of archived subtrees, with their stubs in Global(varname,subs...) nodes and their contents in Backend
+type Tier struct {
+	Global  string  // Global variable name holding stubs, e.g. "^tiering"
+	Backend Backend // Object store holding the archived subtrees
+}
+
+// New is a function to return the tier storing stubs in DefaultGlobal and archived subtrees in the given backend
+func New(backend Backend) *Tier {
+	return &Tier{Global: DefaultGlobal, Backend: backend}
+}
+
+// Archive is a method to move the subtree of varname(subary...) to the backend, leaving a stub, and return whether there
+// was a subtree to archive. If the subtree changes while it is stored, Archive() leaves it in the database and returns
+// ErrChanged. A node that is archived must be restored before it can be archived again.
+func (tier *Tier) Archive(ctx context.Context, varname string, subary []string) (bool, error) {
+	var encoded bytes.Buffer
+
+	stub := tier.stubSubs(varname, subary)
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, tier.Global, stub)
+	if nil != err {
+		return false, err
+	}
+	if 1 == data%10 {
+		return false, ErrArchived
+	}
+	nodes, err := readTree(yottadb.NOTTP, nil, varname, subary)
+	if nil != err || 0 == len(nodes) {
+		return false, err
+	}
+	if err = gob.NewEncoder(&encoded).Encode(nodes); nil != err {
+		return false, err
+	}
+	key := objectKey(varname, subary, encoded.Bytes())
+	if err = tier.Backend.Put(ctx, key, encoded.Bytes()); nil != err {
+		return false, err
+	}
+	_, err = yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		current, err := readTree(tptoken, errstr, varname, subary)
+		if nil != err {
+			return struct{}{}, err
+		}
+		if !equalNodes(nodes, current) {
+			return struct{}{}, ErrChanged
+		}
+		if err = yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_TREE, varname, subary); nil != err {
+			return struct{}{}, err
+		}
+		return struct{}{}, yottadb.SetValE(tptoken, errstr, key, tier.Global, stub)
+	}, "", []string{})
+	if nil != err {
+		tier.Backend.Delete(ctx, key)
+		return false, err
+	}
+	return true, nil
+}
+
+// ArchiveBefore is a method to archive the subtree of each subscript at the next level of varname(subary...) that collates
+// before the given subscript, such as the dates before a cut-off date, and return the number of subtrees archived
+func (tier *Tier) ArchiveBefore(ctx context.Context, varname string, subary []string, before string) (int, error) {
+	var archived int
+	var archerr error
+
+	subs := append(append([]string{}, subary...), "")
+	err := yottadb.SubRangeE(yottadb.NOTTP, nil, varname, subary, "", before, func(sub string) bool {
+		var done bool
+
+		subs[len(subs)-1] = sub
+		if done, archerr = tier.Archive(ctx, varname, subs); done {
+			archived++
+		}
+		return nil == archerr
+	})
+	if nil != archerr {
+		return archived, archerr
+	}
+	return archived, err
+}
+
+// Restore is a method to bring the archived subtree of varname(subary...) back from the backend, and return whether it was
+// restored. If the stub is removed or replaced while the object is read from the backend, as when another process restores
+// the subtree, Restore() leaves the database and the backend unchanged and returns false.
+func (tier *Tier) Restore(ctx context.Context, varname string, subary []string) (bool, error) {
+	var nodes []yottadb.TreeNode
+	var restored bool
+
+	stub := tier.stubSubs(varname, subary)
+	key, err := yottadb.ValE(yottadb.NOTTP, nil, tier.Global, stub)
+	if nil != err {
+		if undefined(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	data, err := tier.Backend.Get(ctx, key)
+	if nil != err {
+		return false, err
+	}
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&nodes); nil != err {
+		return false, err
+	}
+	_, err = yottadb.TpValE(yottadb.NOTTP, nil, func(tptoken uint64, errstr *yottadb.BufferT) (struct{}, error) {
+		// Check the stub again in the transaction, so a subtree restored meanwhile is not overwritten by a stale copy
+		restored = false
+		current, err := yottadb.ValE(tptoken, errstr, tier.Global, stub)
+		if nil != err {
+			if undefined(err) {
+				return struct{}{}, nil
+			}
+			return struct{}{}, err
+		}
+		if current != key {
+			return struct{}{}, nil
+		}
+		for _, node := range nodes {
+			if err := yottadb.SetValE(tptoken, errstr, node.Value, varname, node.Subary); nil != err {
+				return struct{}{}, err
+			}
+		}
+		restored = true
+		return struct{}{}, yottadb.DeleteE(tptoken, errstr, yottadb.YDB_DEL_NODE, tier.Global, stub)
+	}, "", []string{})
+	if nil != err || !restored {
+		return false, err
+	}
+	return true, tier.Backend.Delete(ctx, key)
+}
+
+// ValE is a method to return the value of varname(subary...), first restoring the archived subtree containing the node if
+// the node is not in the database
+func (tier *Tier) ValE(ctx context.Context, varname string, subary []string) (string, error) {
+	for {
+		value, err := yottadb.ValE(yottadb.NOTTP, nil, varname, subary)
+		if !undefined(err) {
+			return value, err
+		}
+		// Restore the outermost archived subtree containing the node, and try again
+		restored := false
+		for i := 0; i <= len(subary) && !restored; i++ {
+			var resterr error
+
+			if restored, resterr = tier.Restore(ctx, varname, subary[:i]); nil != resterr {
+				return "", resterr
+			}
+		}
+		if !restored {
+			// Another process may have restored the subtree meanwhile
+			return yottadb.ValE(yottadb.NOTTP, nil, varname, subary)
+		}
+	}
+}
+
+// stubSubs is a method to return the subscripts of the stub of varname(subary...)
+func (tier *Tier) stubSubs(varname string, subary []string) []string {
+	return append([]string{varname}, subary...)
+}
+
+// objectKey is a function to return the key of the object holding the subtree of varname(subary...) encoded as data. The
+// key depends on the contents as well as the node, so a subtree archived again with other contents gets another key.
+func objectKey(varname string, subary []string, data []byte) string {
+	sum := sha256.Sum256([]byte(yottadb.ZwriteName(varname, subary)))
+	datasum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]) + "-" + hex.EncodeToString(datasum[:8])
+}
+
+// readTree is a function to return the nodes with values in the subtree of varname(subary...), in collation order
+func readTree(tptoken uint64, errstr *yottadb.BufferT, varname string, subary []string) ([]yottadb.TreeNode, error) {
+	var nodes []yottadb.TreeNode
+
+	data, err := yottadb.DataE(tptoken, errstr, varname, subary)
+	if nil != err || 0 == data {
+		return nil, err
+	}
+	subs := subary
+	if 1 != data%10 {
+		subs, err = yottadb.NodeNextE(tptoken, errstr, varname, subs)
+	}
+	for nil == err {
+		var value string
+
+		if !isDescendant(subs, subary) {
+			return nodes, nil // Past the last descendant
+		}
+		if value, err = yottadb.ValE(tptoken, errstr, varname, subs); nil != err {
+			return nil, err
+		}
+		nodes = append(nodes, yottadb.TreeNode{Subary: subs, Value: value})
+		subs, err = yottadb.NodeNextE(tptoken, errstr, varname, subs)
+	}
+	if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+		return nodes, nil
+	}
+	return nil, err
+}
+
+// isDescendant is a function to return whether subs are the subscripts of the node with subscripts subary or of one of its
+// descendants
+func isDescendant(subs, subary []string) bool {
+	if len(subs) < len(subary) {
+		return false
+	}
+	for i := range subary {
+		if subs[i] != subary[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// equalNodes is a function to return whether two lists of nodes read by readTree() have the same subscripts and values
+func equalNodes(nodes1, nodes2 []yottadb.TreeNode) bool {
+	if len(nodes1) != len(nodes2) {
+		return false
+	}
+	for i := range nodes1 {
+		if nodes1[i].Value != nodes2[i].Value || len(nodes1[i].Subary) != len(nodes2[i].Subary) ||
+			!isDescendant(nodes1[i].Subary, nodes2[i].Subary) {
+			return false
+		}
+	}
+	return true
+}
+
+// undefined is a function to return whether err reports an undefined node
+func undefined(err error) bool {
+	return yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err)
+}
@@ -0,0 +1,125 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package tiering_test
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/tiering"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+// memBackend is a Backend keeping objects in memory
+type memBackend map[string][]byte
+
+func (backend memBackend) Put(ctx context.Context, key string, data []byte) error {
+	backend[key] = append([]byte(nil), data...)
+	return nil
+}
+
+func (backend memBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	data, ok := backend[key]
+	if !ok {
+		return nil, errors.New("no such object")
+	}
+	return data, nil
+}
+
+func (backend memBackend) Delete(ctx context.Context, key string) error {
+	delete(backend, key)
+	return nil
+}
+
+func TestTier(t *testing.T) {
+	ctx := context.Background()
+	backend := memBackend{}
+	tier := tiering.New(backend)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^orders", []string{})
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, tier.Global, []string{})
+	for _, date := range []string{"2022-06-30", "2022-12-31", "2023-01-01"} {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, date, "^orders", []string{date}), t)
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "paid", "^orders", []string{date, "1234", "status"}), t)
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "\x00binary\xff", "^orders", []string{date, "1234", "data"}), t)
+	}
+	archived, err := tier.ArchiveBefore(ctx, "^orders", []string{}, "2023-01-01")
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, archived)
+	assert.Equal(t, 2, len(backend))
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, "^orders", []string{"2022-06-30"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+	data, err = yottadb.DataE(yottadb.NOTTP, nil, "^orders", []string{"2023-01-01"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(11), data)
+	_, err = tier.Archive(ctx, "^orders", []string{"2022-06-30"})
+	assert.Equal(t, tiering.ErrArchived, err)
+	// Reading through the tier restores the subtree of the node
+	value, err := tier.ValE(ctx, "^orders", []string{"2022-06-30", "1234", "data"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "\x00binary\xff", value)
+	value, err = yottadb.ValE(yottadb.NOTTP, nil, "^orders", []string{"2022-06-30"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "2022-06-30", value)
+	assert.Equal(t, 1, len(backend))
+	// Nodes that do not exist in any tier are reported as undefined
+	_, err = tier.ValE(ctx, "^orders", []string{"2022-12-31", "9999"})
+	assert.Equal(t, yottadb.YDB_ERR_GVUNDEF, yottadb.ErrorCode(err))
+	assert.Equal(t, 0, len(backend))
+	restored, err := tier.Restore(ctx, "^orders", []string{"2022-12-31"})
+	Assertnoerr(err, t)
+	assert.False(t, restored)
+	archived, err = tier.ArchiveBefore(ctx, "^orders", []string{}, "2022")
+	Assertnoerr(err, t)
+	assert.Equal(t, 0, archived)
+}
+
+// racingBackend is a memBackend whose Get() runs a function first, to change the database while an object is read
+type racingBackend struct {
+	memBackend
+	race func()
+}
+
+func (backend racingBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	backend.race()
+	return backend.memBackend.Get(ctx, key)
+}
+
+func TestTierRestoreRace(t *testing.T) {
+	ctx := context.Background()
+	backend := racingBackend{memBackend: memBackend{}}
+	tier := tiering.New(backend)
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^orders", []string{})
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, tier.Global, []string{})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "paid", "^orders", []string{"2022-06-30", "1234"}), t)
+	archived, err := tier.Archive(ctx, "^orders", []string{"2022-06-30"})
+	Assertnoerr(err, t)
+	assert.True(t, archived)
+	// Another process restores and updates the subtree while the object is read, so the stale copy is not restored
+	backend.race = func() {
+		Assertnoerr(yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, tier.Global, []string{}), t)
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "shipped", "^orders", []string{"2022-06-30", "1234"}), t)
+	}
+	tier.Backend = backend
+	value, err := tier.ValE(ctx, "^orders", []string{"2022-06-30", "1234"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "shipped", value)
+	assert.Equal(t, 1, len(backend.memBackend))
+}
@@ -0,0 +1,173 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+/*
+Package timeseries stores time-stamped values in YottaDB, using the collation of subscripts to scan time ranges.
+
+The points of a series are stored in ^series(name,time) nodes (or in another global variable of the application's
+choosing), where time is the number of nanoseconds since the Unix epoch. The time subscript is zero-padded to 20 digits
+so times always collate as strings in time order - plain numbers of nanoseconds have 19 significant digits, more than
+YottaDB keeps for a number, so would not all collate numerically.
+
+For example:
+
+	series := timeseries.New("cpu")
+	err := series.Append(yottadb.NOTTP, nil, time.Now(), "0.75")
+	...
+	err = series.Range(yottadb.NOTTP, nil, from, to, func(t time.Time, value string) bool {
+		fmt.Println(t, value)
+		return true
+	})
+*/
+package timeseries
+
+import (
+	"errors"
+	"fmt"
+	"lang.yottadb.com/go/yottadb"
+	"math"
+	"strconv"
+	"time"
+)
+
+// DefaultGlobal is the global variable New() stores series in
+const DefaultGlobal string = "^series"
+
+// ErrBeforeEpoch is returned when a time before the Unix epoch (1970-01-01 00:00:00 UTC) is passed to Append()
+var ErrBeforeEpoch = errors.New("timeseries: times before the Unix epoch are not supported")
+
+// Series is a named series of time-stamped values stored in Global(Name,time) nodes
+type Series struct {
+	Global string // Global (or local) variable name holding series, e.g. "^series"
+	Name   string // Name of this series - the first subscript of its nodes
+}
+
+// Bucket holds summary statistics of the points in one interval of a series, as returned by Downsample()
+type Bucket struct {
+	Start time.Time // Start of the interval
+	Count int       // Number of points in the interval
+	Sum   float64   // Sum of the values of the points
+	Min   float64   // Smallest value
+	Max   float64   // Largest value
+	First float64   // Value of the earliest point
+	Last  float64   // Value of the latest point
+}
+
+// New is a function to return the series with the given name, stored in DefaultGlobal
+func New(name string) *Series {
+	return &Series{Global: DefaultGlobal, Name: name}
+}
+
+// Append is a method to add a point with the given time and value to the series. A point already at that time is replaced.
+// Times must not be before the Unix epoch.
+func (series *Series) Append(tptoken uint64, errstr *yottadb.BufferT, t time.Time, value string) error {
+	nsec := t.UnixNano()
+	if 0 > nsec {
+		return ErrBeforeEpoch
+	}
+	return yottadb.SetValE(tptoken, errstr, value, series.Global, []string{series.Name, timeSubscript(nsec)})
+}
+
+// Range is a method to call fn with the time and value of each point in the series at or after from and before to, in time
+// order. Iteration stops early if fn returns false.
+//
+// If a database call returns an error, iteration stops and the method returns the error.
+func (series *Series) Range(tptoken uint64, errstr *yottadb.BufferT, from, to time.Time,
+	fn func(t time.Time, value string) bool) error {
+	var sub string
+
+	if 0 > to.UnixNano() {
+		return nil // The range ends before any point
+	}
+	// Start the scan just before the first possible subscript in the range
+	start := from.UnixNano()
+	if 0 < start {
+		sub = timeSubscript(start - 1)
+	}
+	end := timeSubscript(to.UnixNano())
+	subary := []string{series.Name, sub}
+	for {
+		next, err := yottadb.SubNextE(tptoken, errstr, series.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_NODEEND == yottadb.ErrorCode(err) {
+				return nil
+			}
+			return err
+		}
+		if next >= end {
+			return nil
+		}
+		subary[1] = next
+		nsec, err := strconv.ParseInt(next, 10, 64)
+		if nil != err {
+			return fmt.Errorf("timeseries: invalid time subscript %q in series %s", next, series.Name)
+		}
+		value, err := yottadb.ValE(tptoken, errstr, series.Global, subary)
+		if nil != err {
+			if yottadb.YDB_ERR_GVUNDEF == yottadb.ErrorCode(err) || yottadb.YDB_ERR_LVUNDEF == yottadb.ErrorCode(err) {
+				continue // A point with descendants but no value - not a point
+			}
+			return err
+		}
+		if !fn(time.Unix(0, nsec), value) {
+			return nil
+		}
+	}
+}
+
+// Downsample is a method to summarize the points in the series at or after from and before to in consecutive intervals of
+// the given length starting at from. Only intervals containing points are returned, in time order. Values are parsed as
+// floating point numbers; if a value is not a number, the method returns an error.
+func (series *Series) Downsample(tptoken uint64, errstr *yottadb.BufferT, from, to time.Time,
+	interval time.Duration) ([]Bucket, error) {
+	var buckets []Bucket
+	var parseErr error
+
+	if 0 >= interval {
+		panic("timeseries: interval for Downsample() must be positive")
+	}
+	err := series.Range(tptoken, errstr, from, to, func(t time.Time, value string) bool {
+		num, err := strconv.ParseFloat(value, 64)
+		if nil != err {
+			parseErr = fmt.Errorf("timeseries: value %q at %s in series %s is not a number", value, t, series.Name)
+			return false
+		}
+		start := from.Add(t.Sub(from) / interval * interval)
+		if 0 == len(buckets) || !buckets[len(buckets)-1].Start.Equal(start) {
+			buckets = append(buckets, Bucket{Start: start, Min: math.Inf(1), Max: math.Inf(-1), First: num})
+		}
+		bucket := &buckets[len(buckets)-1]
+		bucket.Count++
+		bucket.Sum += num
+		bucket.Min = math.Min(bucket.Min, num)
+		bucket.Max = math.Max(bucket.Max, num)
+		bucket.Last = num
+		return true
+	})
+	if nil != err {
+		return nil, err
+	}
+	if nil != parseErr {
+		return nil, parseErr
+	}
+	return buckets, nil
+}
+
+// Mean is a method to return the mean of the values in a bucket
+func (bucket *Bucket) Mean() float64 {
+	return bucket.Sum / float64(bucket.Count)
+}
+
+// timeSubscript is a function to return the subscript for a time in nanoseconds since the Unix epoch
+func timeSubscript(nsec int64) string {
+	return fmt.Sprintf("%020d", nsec)
+}
@@ -0,0 +1,94 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package timeseries_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"lang.yottadb.com/go/yottadb/timeseries"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(RunTestsWithDatabase(m))
+}
+
+func TestAppendAndRange(t *testing.T) {
+	var times []time.Time
+	var values []string
+
+	series := timeseries.New("range")
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Times straddling a power of 10 nanoseconds would collate out of order if stored as plain numbers
+	offsets := []time.Duration{time.Second, 0, 2 * time.Second, time.Hour, 3 * time.Second}
+	for i, offset := range offsets {
+		Assertnoerr(series.Append(yottadb.NOTTP, nil, base.Add(offset), strconv.Itoa(i)), t)
+	}
+	Assertnoerr(series.Append(yottadb.NOTTP, nil, time.Unix(0, 999999999999999999), "early"), t)
+	Assertnoerr(series.Append(yottadb.NOTTP, nil, time.Unix(0, 1000000000000000000), "later"), t)
+	assert.Equal(t, timeseries.ErrBeforeEpoch, series.Append(yottadb.NOTTP, nil, time.Unix(-1, 0), "x"))
+	// The range includes from but not to
+	err := series.Range(yottadb.NOTTP, nil, base, base.Add(time.Hour), func(when time.Time, value string) bool {
+		times = append(times, when)
+		values = append(values, value)
+		return true
+	})
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"1", "0", "2", "4"}, values)
+	for i := 1; i < len(times); i++ {
+		assert.True(t, times[i-1].Before(times[i]))
+	}
+	assert.True(t, base.Equal(times[0]))
+	values = nil
+	err = series.Range(yottadb.NOTTP, nil, time.Unix(0, 0), base, func(when time.Time, value string) bool {
+		values = append(values, value)
+		return true
+	})
+	Assertnoerr(err, t)
+	assert.Equal(t, []string{"early", "later"}, values)
+	// Iteration stops when the callback returns false
+	count := 0
+	err = series.Range(yottadb.NOTTP, nil, base, base.Add(2*time.Hour), func(when time.Time, value string) bool {
+		count++
+		return 2 > count
+	})
+	Assertnoerr(err, t)
+	assert.Equal(t, 2, count)
+}
+
+func TestDownsample(t *testing.T) {
+	series := &timeseries.Series{Global: "^tsdown", Name: "temp"}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := map[time.Duration]string{0: "1", 10 * time.Second: "3", 20 * time.Second: "2", 65 * time.Second: "10",
+		3 * time.Minute: "-1"}
+	for offset, value := range points {
+		Assertnoerr(series.Append(yottadb.NOTTP, nil, base.Add(offset), value), t)
+	}
+	buckets, err := series.Downsample(yottadb.NOTTP, nil, base, base.Add(time.Hour), time.Minute)
+	Assertnoerr(err, t)
+	assert.Equal(t, 3, len(buckets))
+	assert.Equal(t, timeseries.Bucket{Start: base, Count: 3, Sum: 6, Min: 1, Max: 3, First: 1, Last: 2}, buckets[0])
+	assert.Equal(t, 2.0, buckets[0].Mean())
+	assert.True(t, base.Add(time.Minute).Equal(buckets[1].Start))
+	assert.Equal(t, 1, buckets[1].Count)
+	assert.True(t, base.Add(3*time.Minute).Equal(buckets[2].Start))
+	assert.Equal(t, -1.0, buckets[2].Min)
+	// Non-numeric values are an error
+	Assertnoerr(series.Append(yottadb.NOTTP, nil, base.Add(2*time.Hour), "hot"), t)
+	_, err = series.Downsample(yottadb.NOTTP, nil, base, base.Add(3*time.Hour), time.Minute)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,81 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceOptions is a structure holding the options of TraceOps()
+type TraceOptions struct {
+	Filter func(op, node string) bool // If not nil, only operations for which Filter returns true are traced
+	Every  int                        // Trace one of every Every operations that pass Filter (all if 0 or 1)
+}
+
+var traceCounter uint64 // Atomic: Number of operations counted towards Every
+
+var traceMutex sync.Mutex  // Protects the fields below and serializes writes to the trace writer
+var traceWriter io.Writer  // Writer receiving the trace
+var traceOpts TraceOptions // Options of the trace
+
+// TraceOps is a function to start writing a line to w for each database operation made by the process, for a lightweight
+// view of what an application does to the database, or to stop if w is nil. Each line has four fields separated by tabs:
+//
+//	op	node	nsec	size
+//
+// where op is the operation (Data, Delete, Get, Incr, NodeNext, NodePrev, Set, SubNext or SubPrev), node is the name of
+// the node in ZWRITE format (e.g. ^x("a",1)), nsec is the duration of the call into YottaDB in nanoseconds and size is the
+// length of the value fetched, set or returned by an increment (0 for other operations). ZWRITE format represents control
+// characters with $CHAR(), so a node name contains no tabs or newlines. Values are not written.
+//
+// With opts, only the operations that pass opts.Filter are traced and, if opts.Every is greater than 1, only one of every
+// opts.Every of those, to bound the cost of tracing a busy process. Lines are written one at a time and errors writing
+// them are ignored. When tracing is stopped, the cost is one atomic load per operation.
+func TraceOps(w io.Writer, opts *TraceOptions) {
+	printEntry("TraceOps()")
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	traceWriter = w
+	traceOpts = TraceOptions{}
+	if nil != opts {
+		traceOpts = *opts
+	}
+	setHook(hookTrace, nil != w)
+}
+
+// writeTrace is a function to write the trace line of an operation if it passes the options of the trace
+func writeTrace(op, node string, elapsed time.Duration, value *BufferT) {
+	size := 0
+	if nil != value {
+		size = len(bufferBytes(value.getCPtr()))
+		runtime.KeepAlive(value)
+	}
+	// Filter is user code that may itself trace or call TraceOps(), so it is called without holding traceMutex
+	traceMutex.Lock()
+	writer, opts := traceWriter, traceOpts
+	traceMutex.Unlock()
+	if nil == writer || (nil != opts.Filter && !opts.Filter(op, node)) {
+		return
+	}
+	if 1 < opts.Every && 0 != atomic.AddUint64(&traceCounter, 1)%uint64(opts.Every) {
+		return
+	}
+	traceMutex.Lock()
+	defer traceMutex.Unlock()
+	fmt.Fprintf(writer, "%s\t%s\t%d\t%d\n", op, node, elapsed.Nanoseconds(), size)
+}
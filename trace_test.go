@@ -0,0 +1,81 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestTraceOps(t *testing.T) {
+	var errstr yottadb.BufferT
+	var trace bytes.Buffer
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	defer yottadb.DeleteE(yottadb.NOTTP, &errstr, yottadb.YDB_DEL_TREE, "^trace", []string{})
+	yottadb.TraceOps(&trace, nil)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "hello", "^trace", []string{"a", "tab\there"}), t)
+	value, err := yottadb.ValE(yottadb.NOTTP, &errstr, "^trace", []string{"a", "tab\there"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "hello", value)
+	_, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^trace", []string{})
+	Assertnoerr(err, t)
+	yottadb.TraceOps(nil, nil)
+	_, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^trace", []string{})
+	Assertnoerr(err, t)
+	lines := strings.Split(strings.TrimSuffix(trace.String(), "\n"), "\n")
+	assert.Equal(t, 3, len(lines))
+	expected := [][]string{{"Set", `^trace("a","tab"_$C(9)_"here")`, "5"}, {"Get", `^trace("a","tab"_$C(9)_"here")`, "5"},
+		{"Data", "^trace", "0"}}
+	for i, line := range lines {
+		fields := strings.Split(line, "\t")
+		assert.Equal(t, 4, len(fields), line)
+		assert.Equal(t, expected[i][0], fields[0])
+		assert.Equal(t, expected[i][1], fields[1])
+		_, err = strconv.ParseInt(fields[2], 10, 64)
+		Assertnoerr(err, t)
+		assert.Equal(t, expected[i][2], fields[3])
+	}
+	// Only operations passing the filter are traced, and with Every only some of them
+	trace.Reset()
+	yottadb.TraceOps(&trace, &yottadb.TraceOptions{Every: 2, Filter: func(op, node string) bool {
+		return "Data" == op
+	}})
+	for i := 0; i < 10; i++ {
+		Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", "^trace", []string{"b"}), t)
+		_, err = yottadb.DataE(yottadb.NOTTP, &errstr, "^trace", []string{"b"})
+		Assertnoerr(err, t)
+	}
+	yottadb.TraceOps(nil, nil)
+	assert.Equal(t, 5, strings.Count(trace.String(), "\n"))
+	assert.Equal(t, 5, strings.Count(trace.String(), "Data\t"))
+	// A filter may itself access the database, which is traced in turn
+	trace.Reset()
+	yottadb.TraceOps(&trace, &yottadb.TraceOptions{Filter: func(op, node string) bool {
+		if "Set" == op {
+			_, err := yottadb.DataE(yottadb.NOTTP, nil, "^trace", []string{"b"})
+			Assertnoerr(err, t)
+		}
+		return true
+	}})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, &errstr, "x", "^trace", []string{"b"}), t)
+	yottadb.TraceOps(nil, nil)
+	assert.Equal(t, 2, strings.Count(trace.String(), "\n"))
+	assert.True(t, strings.HasPrefix(trace.String(), "Data\t"))
+}
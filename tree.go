@@ -0,0 +1,121 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Tree is a literal tree of nodes for SetTreeE(), mapping each subscript to the value of the node or, if the value is
+// itself a Tree or a map[string]interface{}, to the subtree below the node. For example:
+//
+//	yottadb.Tree{"smtp": yottadb.Tree{"host": "mail.example.com", "port": 25}, "retries": 3}
+type Tree map[string]interface{}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Easy API function to set a tree from a literal
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// SetTreeE is an Easy API function to set the nodes described by tree under varname(subary...), so that configuration and
+// other structures can be written as Go literals. For example, with the variable name ^cfg and the Tree shown in the
+// documentation of Tree, SetTreeE() sets ^cfg("retries") to 3, ^cfg("smtp","host") to "mail.example.com" and
+// ^cfg("smtp","port") to 25.
+//
+// Values are formatted as EnsureE() formats default values, and a nil value sets the node to the empty string. If a value
+// is a number YottaDB cannot represent, SetTreeE() returns a NUMOFLOW error and sets no nodes. Nodes not in tree are left
+// unchanged, so a subtree that should hold exactly the nodes of tree should first be deleted. The nodes are set in one
+// transaction, so either all of them are set or none are. SetTreeE() may be called within a transaction, in which case
+// tptoken must be the token of that transaction.
+func SetTreeE(tptoken uint64, errstr *BufferT, tree Tree, varname string, subary []string) error {
+	var nodes []TreeNode
+
+	printEntry("SetTreeE()")
+	nodes, err := appendTreeNodes(tptoken, errstr, nodes, tree, append([]string{}, subary...))
+	if nil != err {
+		return err
+	}
+	_, err = TpValE(tptoken, errstr, func(tptoken uint64, errstr *BufferT) (struct{}, error) {
+		for _, node := range nodes {
+			if err := SetValE(tptoken, errstr, node.Value, varname, node.Subary); nil != err {
+				return struct{}{}, err
+			}
+		}
+		return struct{}{}, nil
+	}, "", []string{})
+	return err
+}
+
+// appendTreeNodes is a function to append the nodes of tree below the node with subscripts subary to nodes. If a value
+// is a number YottaDB cannot represent, it returns the error from formatValue().
+func appendTreeNodes(tptoken uint64, errstr *BufferT, nodes []TreeNode, tree map[string]interface{},
+	subary []string) ([]TreeNode, error) {
+	var err error
+
+	for sub, value := range tree {
+		childsubs := append(subary[:len(subary):len(subary)], sub)
+		switch val := value.(type) {
+		case Tree:
+			nodes, err = appendTreeNodes(tptoken, errstr, nodes, val, childsubs)
+		case map[string]interface{}:
+			nodes, err = appendTreeNodes(tptoken, errstr, nodes, val, childsubs)
+		case nil:
+			nodes = append(nodes, TreeNode{childsubs, ""})
+		default:
+			var str string
+
+			if str, err = formatValue(tptoken, errstr, val); nil == err {
+				nodes = append(nodes, TreeNode{childsubs, str})
+			}
+		}
+		if nil != err {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// formatValue is a function to return the string stored as the value of a node for a Go value given to SetTreeE() or
+// EnsureE(). Numbers are formatted as canonical numbers the way decimalToM() formats them for YottaDB, so M reads them
+// back as the same numbers: integers exactly, floating point values with the fewest digits that convert back to the same
+// value (so 0.1 is ".1"), and big.Rat values exactly or, if they have no exact decimal form, rounded to 18 decimal places.
+// A number YottaDB cannot represent - NaN, an infinity, a magnitude of 1E47 or more, or a non-zero magnitude less than
+// 1E-43 - returns a NUMOFLOW error. Strings and byte slices are used as-is and values of other types are formatted with
+// fmt.Sprintf("%v").
+func formatValue(tptoken uint64, errstr *BufferT, v interface{}) (string, error) {
+	var num *big.Rat
+
+	switch val := v.(type) {
+	case string, []byte:
+		return CanonicalizeSubscript(val), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		num, _ = new(big.Rat).SetString(CanonicalizeSubscript(val))
+	case float32:
+		num, _ = new(big.Rat).SetString(strconv.FormatFloat(float64(val), 'g', -1, 32)) // Fails for NaN and infinities
+	case float64:
+		num, _ = new(big.Rat).SetString(strconv.FormatFloat(val, 'g', -1, 64))
+	case *big.Rat:
+		num = val
+	case *big.Int:
+		num = new(big.Rat).SetInt(val)
+	default:
+		return fmt.Sprintf("%v", v), nil
+	}
+	if nil == num || !numberInRange(num) {
+		return "", NewError(tptoken, errstr, int(YDB_ERR_NUMOFLOW))
+	}
+	return canonicalDecimal(decimalToM(num)), nil
+}
@@ -0,0 +1,53 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+func TestSetTreeE(t *testing.T) {
+	defer yottadb.DeleteE(yottadb.NOTTP, nil, yottadb.YDB_DEL_TREE, "^settree", []string{})
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "kept", "^settree", []string{"cfg", "other"}), t)
+	tree := yottadb.Tree{
+		"smtp":    yottadb.Tree{"host": "mail.example.com", "port": 25},
+		"limits":  map[string]interface{}{"rate": 1.50, "burst": nil},
+		"retries": 3,
+	}
+	Assertnoerr(yottadb.SetTreeE(yottadb.NOTTP, nil, tree, "^settree", []string{"cfg"}), t)
+	expected := map[string][]string{
+		"mail.example.com": {"cfg", "smtp", "host"},
+		"25":               {"cfg", "smtp", "port"},
+		"1.5":              {"cfg", "limits", "rate"},
+		"":                 {"cfg", "limits", "burst"},
+		"3":                {"cfg", "retries"},
+		"kept":             {"cfg", "other"},
+	}
+	for value, subary := range expected {
+		val, err := yottadb.ValE(yottadb.NOTTP, nil, "^settree", subary)
+		Assertnoerr(err, t)
+		assert.Equal(t, value, val)
+	}
+	// Errors setting a node are returned
+	err := yottadb.SetTreeE(yottadb.NOTTP, nil, yottadb.Tree{"a": 1}, "^1bad", []string{})
+	assert.Equal(t, yottadb.YDB_ERR_INVVARNAME, yottadb.ErrorCode(err))
+	// Numbers YottaDB cannot represent are refused before any node is set
+	err = yottadb.SetTreeE(yottadb.NOTTP, nil, yottadb.Tree{"a": 1, "b": yottadb.Tree{"c": 1e300}}, "^settree", []string{"big"})
+	assert.Equal(t, yottadb.YDB_ERR_NUMOFLOW, yottadb.ErrorCode(err))
+	data, err := yottadb.DataE(yottadb.NOTTP, nil, "^settree", []string{"big"})
+	Assertnoerr(err, t)
+	assert.Equal(t, uint32(0), data)
+}
@@ -0,0 +1,81 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Typed access to intrinsic special variables used to tune the behavior of the engine. These
+// save applications from having to know the names and formats of the variables.
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// MaxTPTimeE is an Easy API function to return the current value of $ZMAXTPTIME, the maximum time a transaction (TpE()
+// or TpST()) may take before YottaDB aborts it with the TPTIMEOUT error. A value of zero means there is no limit.
+func MaxTPTimeE(tptoken uint64, errstr *BufferT) (time.Duration, error) {
+	printEntry("MaxTPTimeE()")
+	val, err := ValE(tptoken, errstr, "$ZMAXTPTIME", []string{})
+	if nil != err {
+		return 0, err
+	}
+	secs, err := strconv.ParseInt(val, 10, 64)
+	if nil != err {
+		panic(fmt.Sprintf("YDB: Unexpected value of $ZMAXTPTIME: %s", val))
+	}
+	return time.Duration(secs) * time.Second, nil
+}
+
+// SetMaxTPTimeE is an Easy API function to set $ZMAXTPTIME, the maximum time a transaction (TpE() or TpST()) may take
+// before YottaDB aborts it with the TPTIMEOUT error. A value of zero removes the limit. As $ZMAXTPTIME is a number of
+// seconds, the duration must be a whole number of seconds that is not negative and fits in 32 bits, otherwise the
+// INVTUNABLE error is returned.
+//
+// The new value applies to transactions started after it is set.
+func SetMaxTPTimeE(tptoken uint64, errstr *BufferT, maxTime time.Duration) error {
+	printEntry("SetMaxTPTimeE()")
+	if 0 > maxTime || 0 != maxTime%time.Second || math.MaxInt32 < maxTime/time.Second {
+		return newInvTunableError("$ZMAXTPTIME", maxTime.String())
+	}
+	return SetValE(tptoken, errstr, strconv.FormatInt(int64(maxTime/time.Second), 10), "$ZMAXTPTIME", []string{})
+}
+
+// GblDirE is an Easy API function to return the current value of $ZGBLDIR, the path of the global directory used to map
+// global variables to database files.
+func GblDirE(tptoken uint64, errstr *BufferT) (string, error) {
+	printEntry("GblDirE()")
+	return ValE(tptoken, errstr, "$ZGBLDIR", []string{})
+}
+
+// SetGblDirE is an Easy API function to set $ZGBLDIR, switching the process to the given global directory for subsequent
+// global variable references. An empty path returns the INVTUNABLE error rather than switching to a global directory
+// that cannot be opened.
+func SetGblDirE(tptoken uint64, errstr *BufferT, gblDir string) error {
+	printEntry("SetGblDirE()")
+	if "" == gblDir {
+		return newInvTunableError("$ZGBLDIR", "\"\"")
+	}
+	return SetValE(tptoken, errstr, gblDir, "$ZGBLDIR", []string{})
+}
+
+// newInvTunableError is a function to create the INVTUNABLE error for an invalid value of an intrinsic special variable.
+func newInvTunableError(isv, value string) error {
+	errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVTUNABLE), "!AD", isv+"="+value, 1)
+	return &YDBError{errcode: (int)(YDB_ERR_INVTUNABLE), errmsg: errmsg}
+}
@@ -0,0 +1,59 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMaxTPTimeE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	orig, err := yottadb.MaxTPTimeE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	defer yottadb.SetMaxTPTimeE(yottadb.NOTTP, &errstr, orig)
+	err = yottadb.SetMaxTPTimeE(yottadb.NOTTP, &errstr, 30*time.Second)
+	Assertnoerr(err, t)
+	maxTime, err := yottadb.MaxTPTimeE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, 30*time.Second, maxTime)
+	// Values that are not a whole, non-negative number of seconds are rejected
+	for _, bad := range []time.Duration{-time.Second, 1500 * time.Millisecond} {
+		err = yottadb.SetMaxTPTimeE(yottadb.NOTTP, &errstr, bad)
+		assert.Equal(t, yottadb.YDB_ERR_INVTUNABLE, yottadb.ErrorCode(err))
+	}
+	maxTime, err = yottadb.MaxTPTimeE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, 30*time.Second, maxTime)
+}
+
+func TestGblDirE(t *testing.T) {
+	var errstr yottadb.BufferT
+
+	errstr.Alloc(128)
+	defer errstr.Free()
+	gblDir, err := yottadb.GblDirE(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, os.Getenv("ydb_gbldir"), gblDir)
+	err = yottadb.SetGblDirE(yottadb.NOTTP, &errstr, "")
+	assert.Equal(t, yottadb.YDB_ERR_INVTUNABLE, yottadb.ErrorCode(err))
+	err = yottadb.SetGblDirE(yottadb.NOTTP, &errstr, gblDir)
+	Assertnoerr(err, t)
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -15,6 +15,8 @@ package yottadb
 import (
 	"fmt"
 	"runtime"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"unsafe"
 )
@@ -79,16 +81,16 @@ func (imdesc *internalCallMDesc) Free() {
 	cindPtr := imdesc.cmdesc
 	if nil != cindPtr {
 		if nil != cindPtr.rtn_name.address {
-			freeMem(unsafe.Pointer(cindPtr.rtn_name.address), C.size_t(cindPtr.rtn_name.length))
+			freeMem(unsafe.Pointer(cindPtr.rtn_name.address), C.size_t(cindPtr.rtn_name.length+1), memCatCallMDesc)
 			cindPtr.rtn_name.address = nil
 		}
-		freeMem(unsafe.Pointer(cindPtr), C.size_t(C.sizeof_ci_name_descriptor))
+		freeMem(unsafe.Pointer(cindPtr), C.size_t(C.sizeof_ci_name_descriptor), memCatCallMDesc)
 		// The below keeps imdesc around long enough to get rid of this block's C memory. No KeepAlive() necessary.
 		imdesc.cmdesc = nil
 	}
 	ctypPtr := imdesc.parmtyps
 	if nil != ctypPtr {
-		freeMem(unsafe.Pointer(ctypPtr), C.size_t(C.sizeof_ci_parm_type))
+		freeMem(unsafe.Pointer(ctypPtr), C.size_t(C.sizeof_ci_parm_type), memCatCallMDesc)
 		imdesc.parmtyps = nil
 	}
 }
@@ -115,16 +117,16 @@ func (mdesc *CallMDesc) SetRtnName(rtnname string) {
 		if nil == cindPtr.rtn_name.address {
 			panic("YDB: Routine name address is nil - out of design situation")
 		}
-		freeMem(unsafe.Pointer(cindPtr.rtn_name.address), C.size_t(cindPtr.rtn_name.length))
+		freeMem(unsafe.Pointer(cindPtr.rtn_name.address), C.size_t(cindPtr.rtn_name.length+1), memCatCallMDesc)
 		cindPtr.rtn_name.address = nil
 	} else {
-		cindPtr = (*C.ci_name_descriptor)(allocMem(C.size_t(C.sizeof_ci_name_descriptor)))
-		ctypPtr = (*C.ci_parm_type)(allocMem(C.size_t(C.sizeof_ci_parm_type)))
+		cindPtr = (*C.ci_name_descriptor)(allocMem(C.size_t(C.sizeof_ci_name_descriptor), memCatCallMDesc))
+		ctypPtr = (*C.ci_parm_type)(allocMem(C.size_t(C.sizeof_ci_parm_type), memCatCallMDesc))
 		mdesc.cmdesc = &internalCallMDesc{rtnname, false, cindPtr, ctypPtr}
 		// Set a finalizer so this block is released when garbage collected
 		runtime.SetFinalizer(mdesc.cmdesc, func(o *internalCallMDesc) { o.Free() })
 	}
-	cindPtr.rtn_name.address = C.CString(rtnname) // Allocates new memory we need to release when done (done by finalizer)
+	cindPtr.rtn_name.address = allocCString(rtnname, memCatCallMDesc) // Allocates new memory we need to release when done (done by finalizer)
 	cindPtr.rtn_name.length = C.ulong(rtnnamelen)
 	cindPtr.handle = nil
 	mdesc.cmdesc.filledin = false // Mark parm type struct as having been NOT filled in yet
@@ -133,6 +135,15 @@ func (mdesc *CallMDesc) SetRtnName(rtnname string) {
 
 // CallMDescT allows calls to M with string arguments and an optional string return value if the called function returns one
 // and a return value is described in the call-in definition. Else return is nil.
+//
+// Arguments of most types are passed as their value formatted with fmt.Sprintf("%v"), dereferencing pointers to the basic
+// types. A []byte or *BufferT argument is passed as its bytes, unchanged. A *KeyT argument is passed as the name of its
+// node in ZWRITE format (e.g. ^x("a",1)), so the M routine can reference the node with indirection (e.g. @name).
+//
+// The return value may be at most retvallen bytes long. If the routine returns a longer value, the first retvallen bytes of
+// it are returned along with the CALLMTRUNC error, rather than silently returning a truncated value. The routine is not
+// called again, as it may have side effects, so callers that cannot bound the length of the return value should pass a
+// generous retvallen or return long values through a global variable instead.
 func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen uint32, rtnargs ...interface{}) (string, error) {
 	var vplist variadicPlist
 	var parmIndx, inmask, outmask, imask, omask uint32
@@ -165,6 +176,9 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return "", err
+	}
 	// If we haven't already fetched the call description from YDB, do that now
 	if !mdesc.cmdesc.filledin {
 		if nil != errstr {
@@ -172,7 +186,7 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 		}
 		rc := C.ydb_ci_get_info_t(C.uint64_t(tptoken), cbuft, mdesc.cmdesc.cmdesc.rtn_name.address, mdesc.cmdesc.parmtyps)
 		if YDB_OK != rc {
-			err := NewError(tptoken, errstr, int(rc))
+			err := engineError(tptoken, errstr, int(rc), "CallMDesc.CallMDescT")
 			return "", err
 		}
 		mdesc.cmdesc.filledin = true
@@ -200,11 +214,12 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 	parmIndx++
 	// Setup return value if any (first variable parm)
 	if 0 != retvallen {
-		retvalPtr = (*C.ydb_string_t)(allocMem(C.size_t(C.sizeof_ydb_string_t)))
-		defer freeMem(unsafe.Pointer(retvalPtr), C.size_t(C.sizeof_ydb_string_t)) // Free this when we are done
-		retvalPtr.address = (*C.char)(allocMem(C.size_t(retvallen)))
-		defer freeMem(unsafe.Pointer(retvalPtr.address), C.size_t(retvallen))
-		retvalPtr.length = (C.ulong)(retvallen)
+		retvalPtr = (*C.ydb_string_t)(allocMem(C.size_t(C.sizeof_ydb_string_t), memCatParms))
+		defer freeMem(unsafe.Pointer(retvalPtr), C.size_t(C.sizeof_ydb_string_t), memCatParms) // Free this when we are done
+		// Allow one more byte than requested so a return value longer than retvallen can be detected
+		retvalPtr.address = (*C.char)(allocMem(C.size_t(retvallen+1), memCatParms))
+		defer freeMem(unsafe.Pointer(retvalPtr.address), C.size_t(retvallen+1), memCatParms)
+		retvalPtr.length = (C.ulong)(retvallen + 1)
 		err = vplist.setVPlistParam(tptoken, errstr, parmIndx, uintptr(unsafe.Pointer(retvalPtr)))
 		if nil != err {
 			return "", err
@@ -223,8 +238,8 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 		panic(fmt.Sprintf("YDB: Parm count of %d exceeds maximum parm count of %d", parmcnt, int(C.YDB_MAX_PARMS)))
 	}
 	allocLen := C.size_t(C.sizeof_ydb_string_t * parmcnt)
-	parmblkPtr := (*C.ydb_string_t)(allocMem(allocLen))
-	defer freeMem(unsafe.Pointer(parmblkPtr), allocLen)
+	parmblkPtr := (*C.ydb_string_t)(allocMem(allocLen, memCatParms))
+	defer freeMem(unsafe.Pointer(parmblkPtr), allocLen, memCatParms)
 	parmPtr := parmblkPtr
 	inmask = uint32(mdesc.cmdesc.parmtyps.input_mask)
 	outmask = uint32(mdesc.cmdesc.parmtyps.output_mask)
@@ -283,6 +298,21 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 			case *complex128:
 				cmplx128Ptr = rtnargs[i].(*complex128)
 				strparm = fmt.Sprintf("%v", *cmplx128Ptr)
+			case []byte:
+				// Binary data - passed as-is rather than formatted as a list of numbers
+				strparm = string(rtnargs[i].([]byte))
+			case *BufferT:
+				strparm, err = rtnargs[i].(*BufferT).ValStr(tptoken, errstr)
+				if nil != err {
+					return "", err
+				}
+			case *KeyT:
+				// A key is passed as the name of its node in ZWRITE format so M code can use it with indirection
+				key := rtnargs[i].(*KeyT)
+				if nil == key || nil == key.Varnm || nil == key.Subary {
+					panic("YDB: KeyT passed to a call-in routine is not allocated")
+				}
+				strparm = key.zwriteName()
 			default:
 				// Assume passed by value - this generic string conversion suffices
 				strparm = fmt.Sprintf("%v", rtnargs[i])
@@ -295,8 +325,8 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 			parmPtr.length = C.ulong(len(strparm))
 			if 0 < parmPtr.length {
 				// Check if parm is pass-by-value or pass-by-reference by checking ci info
-				parmPtr.address = C.CString(strparm)
-				defer freeMem(unsafe.Pointer(parmPtr.address), C.size_t(parmPtr.length))
+				parmPtr.address = allocCString(strparm, memCatParms)
+				defer freeMem(unsafe.Pointer(parmPtr.address), C.size_t(parmPtr.length+1), memCatParms)
 			} else {
 				parmPtr.address = nil
 			}
@@ -320,8 +350,8 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 			// Setup ydb_string_t (parmPtr) to point to our string
 			parmPtr.length = C.ulong(len(*pval))
 			if 0 < parmPtr.length {
-				parmPtr.address = (*C.char)(allocMem(C.size_t(parmPtr.length)))
-				defer freeMem(unsafe.Pointer(parmPtr.address), C.size_t(parmPtr.length))
+				parmPtr.address = (*C.char)(allocMem(C.size_t(parmPtr.length), memCatParms))
+				defer freeMem(unsafe.Pointer(parmPtr.address), C.size_t(parmPtr.length), memCatParms)
 			} else {
 				parmPtr.address = nil
 			}
@@ -343,12 +373,18 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 	// the cgo header in order to drive ydb_cip_t().
 	rc := vplist.callVariadicPlistFunc(C.ydb_get_cipt_funcvp()) // Drive ydb_cip_t()
 	if YDB_OK != rc {
-		err = NewError(tptoken, errstr, int(rc))
+		err = engineError(tptoken, errstr, int(rc), "CallMDesc.CallMDescT")
 		return "", err
 	}
 	if 0 != retvallen { // If we have a return value
 		// Build a string of the length of the return value
 		retval = C.GoStringN(retvalPtr.address, C.int(retvalPtr.length))
+		if uint32(len(retval)) > retvallen {
+			// The value did not fit - return what was asked for with an error rather than quietly truncating it
+			retval = retval[:retvallen]
+			errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_CALLMTRUNC), "!AD", strconv.Itoa(int(retvallen)), 1)
+			err = &YDBError{errcode: (int)(YDB_ERR_CALLMTRUNC), errmsg: errmsg}
+		}
 	} else {
 		retval = ""
 	}
@@ -366,7 +402,7 @@ func (mdesc *CallMDesc) CallMDescT(tptoken uint64, errstr *BufferT, retvallen ui
 	runtime.KeepAlive(vplist)
 	runtime.KeepAlive(rtnargs)
 	runtime.KeepAlive(errstr)
-	return retval, nil
+	return retval, err
 }
 
 // Methods for CallMTable struct
@@ -383,13 +419,16 @@ func (newcmtable *CallMTable) CallMTableSwitchT(tptoken uint64, errstr *BufferT)
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return nil, err
+	}
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
 	rc := C.ydb_ci_tab_switch_t(C.uint64_t(tptoken), cbuft, C.uintptr_t(newcmtable.handle),
 		(*C.uintptr_t)(unsafe.Pointer(&callmtabret.handle)))
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "CallMTable.CallMTableSwitchT")
 		return nil, err
 	}
 	runtime.KeepAlive(errstr)
@@ -405,7 +444,8 @@ func (newcmtable *CallMTable) CallMTableSwitchT(tptoken uint64, errstr *BufferT)
 // CallMT allows calls to M with string arguments and an optional string return value if the called function returns one
 // and a return value is described in the call-in definition. Else return is nil. This function differs from CallMDescT()
 // in that the name of the routine is specified here and must always be looked up in the routine list. To avoid having
-// two routines nearly identical, this routine is written to invoke CallMDescT().
+// two routines nearly identical, this routine is written to invoke CallMDescT(). As with CallMDescT(), a return value
+// longer than retvallen is truncated and returned with the CALLMTRUNC error.
 func CallMT(tptoken uint64, errstr *BufferT, retvallen uint32, rtnname string, rtnargs ...interface{}) (string, error) {
 	var mdesc CallMDesc
 
@@ -417,6 +457,45 @@ func CallMT(tptoken uint64, errstr *BufferT, retvallen uint32, rtnname string, r
 	return mdesc.CallMDescT(tptoken, errstr, retvallen, rtnargs...)
 }
 
+// CallMTxnT is a variant of CallMT() for calls made from within transactions, and for calls to M routines that themselves
+// use TSTART, TCOMMIT or TROLLBACK. Within a transaction, tptoken must be the token of the transaction (as for any other
+// call), which is passed on to the M routine so it runs as part of the transaction, or NOTTP if SetImplicitTPToken() is in
+// effect. After the call, $TLEVEL is checked against its value before the call, and if the routine committed or rolled back
+// a transaction it did not start, or started one it did not commit or roll back, its return value is returned with the
+// TLEVELCHANGE error. The transaction state is then not what the caller expects, so the caller should treat the error as
+// fatal to any transaction it is running.
+func CallMTxnT(tptoken uint64, errstr *BufferT, retvallen uint32, rtnname string, rtnargs ...interface{}) (string, error) {
+	var tlevelBefore, tlevelAfter int
+	var retval string
+	var err error
+
+	printEntry("CallMTxnT()")
+	if 1 != atomic.LoadUint32(&ydbInitialized) {
+		initializeYottaDB()
+	}
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return "", err
+	}
+	tlevelBefore, err = TLevelE(tptoken, errstr)
+	if nil != err {
+		return "", err
+	}
+	retval, err = CallMT(tptoken, errstr, retvallen, rtnname, rtnargs...)
+	if nil != err {
+		return retval, err
+	}
+	tlevelAfter, err = TLevelE(tptoken, errstr)
+	if nil != err {
+		return retval, err
+	}
+	if tlevelAfter != tlevelBefore {
+		errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_TLEVELCHANGE), "!AD",
+			fmt.Sprintf("%s changed $TLEVEL from %d to %d", rtnname, tlevelBefore, tlevelAfter), 1)
+		return retval, &YDBError{errcode: (int)(YDB_ERR_TLEVELCHANGE), errmsg: errmsg}
+	}
+	return retval, nil
+}
+
 // CallMTableOpenT function opens a new call table or one for which the process had no handle and returns a
 // CallMTable for it.
 func CallMTableOpenT(tptoken uint64, errstr *BufferT, tablename string) (*CallMTable, error) {
@@ -426,16 +505,20 @@ func CallMTableOpenT(tptoken uint64, errstr *BufferT, tablename string) (*CallMT
 	if 1 != atomic.LoadUint32(&ydbInitialized) {
 		initializeYottaDB()
 	}
-	cstr := C.CString(tablename)
-	defer C.free(unsafe.Pointer(cstr))
+	if err := checkNestedTPToken(&tptoken); nil != err {
+		return nil, err
+	}
+	cstr := allocCString(tablename, memCatParms)
+	defer freeMem(unsafe.Pointer(cstr), C.size_t(len(tablename)+1), memCatParms)
 	if nil != errstr {
 		cbuft = errstr.getCPtr()
 	}
 	rc := C.ydb_ci_tab_open_t(C.uint64_t(tptoken), cbuft, cstr, (*C.uintptr_t)(unsafe.Pointer(&callmtab.handle)))
 	if YDB_OK != rc {
-		err := NewError(tptoken, errstr, int(rc))
+		err := engineError(tptoken, errstr, int(rc), "CallMTableOpenT")
 		return nil, err
 	}
+	callMTableFiles.Store(callmtab.handle, tablename) // Remember the file for CallMTable.Routines()
 	runtime.KeepAlive(errstr)
 	return &callmtab, nil
 }
@@ -473,6 +556,9 @@ func MessageT(tptoken uint64, errstr *BufferT, status int) (string, error) {
 			// error return value.
 			return "%YDB-E-CALLINAFTERXIT, After a ydb_exit(), a process cannot create a valid YottaDB context", nil
 		}
+		if err := checkNestedTPToken(&tptoken); nil != err {
+			return "", err
+		}
 		defer msgval.Free()
 		msgval.Alloc(uint32(YDB_MAX_ERRORMSG))
 		if nil != errstr {
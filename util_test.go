@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2019-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2019-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -94,6 +94,33 @@ func TestCallMDescTWithArgs(t *testing.T) {
 	assert.Equal(t, "parm3parm2parm1", retval)
 }
 
+func TestCallMTByteAndKeyArgs(t *testing.T) {
+	var key yottadb.KeyT
+
+	envvarSave := make(map[string]string)
+	saveEnvvars(t, &envvarSave, "ydb_ci", "ydb_routines")
+	err := os.Setenv("ydb_ci", "calltab.ci")
+	assert.Nil(t, err)
+	includeInEnvvar(t, "ydb_routines", "./m_routines")
+	defer restoreEnvvars(t, &envvarSave, "ydb_ci", "ydb_routines")
+	err = yottadb.SetValE(yottadb.NOTTP, nil, "node value", "^callmargs", []string{"a", "1"})
+	assert.Nil(t, err)
+	key.Alloc(16, 2, 16)
+	defer key.Free()
+	err = key.Varnm.SetValStr(yottadb.NOTTP, nil, "^callmargs")
+	assert.Nil(t, err)
+	err = key.Subary.SetValStr(yottadb.NOTTP, nil, 0, "a")
+	assert.Nil(t, err)
+	err = key.Subary.SetValStr(yottadb.NOTTP, nil, 1, "1")
+	assert.Nil(t, err)
+	err = key.Subary.SetElemUsed(yottadb.NOTTP, nil, 2)
+	assert.Nil(t, err)
+	// A []byte is passed unchanged and a *KeyT by the name of its node
+	retval, err := yottadb.CallMT(yottadb.NOTTP, nil, 64, "CallMArgs", []byte{'a', 0, 255}, &key)
+	assert.Nil(t, err)
+	assert.Equal(t, "3:255:node value", retval)
+}
+
 func TestCallMT(t *testing.T) {
 	envvarSave := make(map[string]string)
 	saveEnvvars(t, &envvarSave, "ydb_ci", "ydb_routines")
@@ -117,11 +144,9 @@ func TestCallMT(t *testing.T) {
 		panic(fmt.Sprintf("CallMT() return is not the correct length. Got: %d; Expected: 20", len(retval)))
 	}
 
-	/* M callin that returns 20 characters, using a 15 character buffer; should return truncated string */
+	/* M callin that returns 20 characters, using a 15 character buffer; should return truncated string and CALLMTRUNC */
 	retval, err = yottadb.CallMT(yottadb.NOTTP, &errstr, 15, "CallMTStrTest")
-	if nil != err {
-		panic(err)
-	}
+	assert.Equal(t, yottadb.YDB_ERR_CALLMTRUNC, yottadb.ErrorCode(err))
 	if "a0a1a2a3a4a5a6a" != retval {
 		panic(fmt.Sprintf("CallMT() did not return the correct string. Got: %s; Expected: a0a1a2a3a4a5a6a", retval))
 	}
@@ -158,11 +183,9 @@ func TestCallMDescT(t *testing.T) {
 		panic(fmt.Sprintf("CallMT() return is not the correct length. Got: %d; Expected: 20", len(retval)))
 	}
 
-	/* M callin that returns 20 characters, using a 15 character buffer; should return INVSTRLEN */
+	/* M callin that returns 20 characters, using a 15 character buffer; should return truncated string and CALLMTRUNC */
 	retval, err = callin.CallMDescT(yottadb.NOTTP, &errstr, 15)
-	if nil != err {
-		panic(err)
-	}
+	assert.Equal(t, yottadb.YDB_ERR_CALLMTRUNC, yottadb.ErrorCode(err))
 	if "a0a1a2a3a4a5a6a" != retval {
 		panic(fmt.Sprintf("CallMT() did not return the correct string. Got: %s; Expected: a0a1a2a3a4a5a6a", retval))
 	}
@@ -210,3 +233,39 @@ func TestCallMTab(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "entry called", retval)
 }
+
+func TestCallMTxnT(t *testing.T) {
+	var errstr yottadb.BufferT
+	var builder yottadb.CallMTableBuilder
+
+	envvarSave := make(map[string]string)
+	saveEnvvars(t, &envvarSave, "ydb_routines")
+	includeInEnvvar(t, "ydb_routines", "./m_routines")
+	defer restoreEnvvars(t, &envvarSave, "ydb_routines")
+	errstr.Alloc(128)
+	defer errstr.Free()
+	builder.Func("TLevelStart", yottadb.CallMVoid, "start^tlevel").Func("TLevelRollback", yottadb.CallMVoid, "rollback^tlevel")
+	builder.Func("TLevelHello", yottadb.CallMString, "entry^helloworld1")
+	calltab, err := builder.OpenT(yottadb.NOTTP, &errstr)
+	assert.Nil(t, err)
+	prevtab, err := calltab.CallMTableSwitchT(yottadb.NOTTP, &errstr)
+	assert.Nil(t, err)
+	defer prevtab.CallMTableSwitchT(yottadb.NOTTP, &errstr)
+	// A routine that leaves a transaction open, and one that rolls it back, both change $TLEVEL
+	_, err = yottadb.CallMTxnT(yottadb.NOTTP, &errstr, 0, "TLevelStart")
+	assert.Equal(t, yottadb.YDB_ERR_TLEVELCHANGE, yottadb.ErrorCode(err))
+	assert.Contains(t, err.Error(), "TLevelStart changed $TLEVEL from 0 to 1")
+	_, err = yottadb.CallMTxnT(yottadb.NOTTP, &errstr, 0, "TLevelRollback")
+	assert.Equal(t, yottadb.YDB_ERR_TLEVELCHANGE, yottadb.ErrorCode(err))
+	tlevel, err := yottadb.TLevelE(yottadb.NOTTP, &errstr)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, tlevel)
+	// Within a transaction, the call runs with the transaction's tptoken and sees its $TLEVEL
+	err = yottadb.TpE(yottadb.NOTTP, &errstr, func(tptoken uint64, errstr *yottadb.BufferT) int32 {
+		retval, err := yottadb.CallMTxnT(tptoken, errstr, 64, "TLevelHello")
+		assert.Nil(t, err)
+		assert.Equal(t, "entry called", retval)
+		return yottadb.YDB_OK
+	}, "BATCH", []string{})
+	assert.Nil(t, err)
+}
@@ -0,0 +1,113 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// validatorEntry is a validator set by SetValidator() with the pattern of the global variable names it applies to
+type validatorEntry struct {
+	pattern   string
+	validator func(node, value string) error
+}
+
+var validatorMutex sync.Mutex // Serializes changes to validators
+var validators atomic.Value   // []validatorEntry: Validators in the order they were set, replaced rather than changed
+
+// SetValidator is a function to set a validator called before each set of a node of the global variables whose names
+// match pattern, so that the data written to them can be checked in one place. The validator is called with the name
+// of the node in ZWRITE format and the value to set; if it returns an error, the set returns that error without
+// calling YottaDB and the node is unchanged. A nil validator removes the validator set for pattern.
+//
+// The pattern is matched against the name without its leading caret with the syntax of path.Match(), as by
+// DeleteGlobalsE(), so "order*" applies to ^order and ^orders. SetValidator() returns an error if the pattern is malformed.
+// Setting a validator for a pattern that already has one replaces it; when several patterns match a global variable,
+// their validators are called in the order they were first set and the first error is returned.
+//
+// Validators are called by SetValE() and KeyT.SetValST(), and so by the functions built on them, on the goroutine making
+// the set. IncrE() and KeyT.IncrST() increment a node of a global variable with a validator as IncrClampE() does, reading
+// and setting it in a transaction, so that the validators are called with the incremented value. Validators are not
+// called for local variables or for changes made by DeleteE() or M code. Validators set or removed while a set runs
+// apply from the next set.
+func SetValidator(pattern string, validator func(node, value string) error) error {
+	printEntry("SetValidator()")
+	pattern = strings.TrimPrefix(pattern, "^")
+	if _, err := path.Match(pattern, ""); nil != err {
+		return err
+	}
+	validatorMutex.Lock()
+	defer validatorMutex.Unlock()
+	entries, _ := validators.Load().([]validatorEntry)
+	i := 0
+	for i < len(entries) && pattern != entries[i].pattern {
+		i++
+	}
+	// Sets may be reading the current slice, so changes are made to a copy
+	switch {
+	case len(entries) == i:
+		if nil != validator {
+			entries = append(entries[:i:i], validatorEntry{pattern, validator})
+		}
+	case nil == validator:
+		entries = append(entries[:i:i], entries[i+1:]...)
+	default:
+		entries = append([]validatorEntry(nil), entries...)
+		entries[i].validator = validator
+	}
+	validators.Store(entries)
+	setHook(hookValidate, 0 != len(entries))
+	return nil
+}
+
+// hasValidator is a function to return whether a validator applies to the variable varname
+func hasValidator(varname string) bool {
+	if !strings.HasPrefix(varname, "^") {
+		return false
+	}
+	entries, _ := validators.Load().([]validatorEntry)
+	for _, entry := range entries {
+		if matched, _ := path.Match(entry.pattern, varname[1:]); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// validateSet is a function to call the validators matching varname before a value is set into one of its nodes. The
+// name of the node and the value are only fetched if a validator matches, so sets of other global variables cost a
+// path.Match() per validator and allocate nothing.
+func validateSet(varname string, node, value func() string) error {
+	var name, val string
+
+	if !strings.HasPrefix(varname, "^") {
+		return nil
+	}
+	entries, _ := validators.Load().([]validatorEntry)
+	fetched := false
+	for _, entry := range entries {
+		if matched, _ := path.Match(entry.pattern, varname[1:]); !matched {
+			continue
+		}
+		if !fetched {
+			name, val, fetched = node(), value(), true
+		}
+		if err := entry.validator(name, val); nil != err {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"strconv"
+	"testing"
+)
+
+func TestSetValidator(t *testing.T) {
+	var errstr yottadb.BufferT
+	var key yottadb.KeyT
+	var value yottadb.BufferT
+
+	errNotNumber := errors.New("not a number")
+	Assertnoerr(yottadb.SetValidator("^valid*", func(node, value string) error {
+		if _, err := strconv.Atoi(value); nil != err {
+			return errNotNumber
+		}
+		return nil
+	}), t)
+	defer yottadb.SetValidator("^valid*", nil)
+	assert.NotNil(t, yottadb.SetValidator("[", nil))
+
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "42", "^validated", []string{"a"}), t)
+	err := yottadb.SetValE(yottadb.NOTTP, nil, "forty-two", "^validated", []string{"a"})
+	assert.Equal(t, errNotNumber, err)
+	// Other global variables and local variables are not validated
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "forty-two", "^other", []string{"a"}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "forty-two", "validated", []string{"a"}), t)
+
+	errstr.Alloc(yottadb.YDB_MAX_ERRORMSG)
+	defer errstr.Free()
+	key.Alloc(64, 1, 64)
+	defer key.Free()
+	value.Alloc(64)
+	defer value.Free()
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, &errstr, "^validated"), t)
+	Assertnoerr(key.Subary.SetValStr(yottadb.NOTTP, &errstr, 0, "a"), t)
+	Assertnoerr(key.Subary.SetElemUsed(yottadb.NOTTP, &errstr, 1), t)
+	Assertnoerr(value.SetValStr(yottadb.NOTTP, &errstr, "bad"), t)
+	assert.Equal(t, errNotNumber, key.SetValST(yottadb.NOTTP, &errstr, &value))
+
+	// A second validator is called after the first, and removing a validator leaves the others
+	Assertnoerr(yottadb.SetValidator("validated", func(node, value string) error {
+		if `^validated("a")` == node && 2 < len(value) {
+			return errors.New("too long")
+		}
+		return nil
+	}), t)
+	defer yottadb.SetValidator("validated", nil)
+	assert.EqualError(t, yottadb.SetValE(yottadb.NOTTP, nil, "100", "^validated", []string{"a"}), "too long")
+	Assertnoerr(yottadb.SetValidator("valid*", nil), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "xy", "^validated", []string{"a"}), t)
+	val, err := yottadb.ValE(yottadb.NOTTP, nil, "^validated", []string{"a"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "xy", val)
+
+	// The name of the node is only formatted for the validators that match, so other sets allocate nothing more than
+	// without validators
+	Assertnoerr(value.SetValStr(yottadb.NOTTP, &errstr, "1"), t)
+	set := func() {
+		Assertnoerr(key.SetValST(yottadb.NOTTP, &errstr, &value), t)
+	}
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, &errstr, "^other"), t)
+	unmatched := testing.AllocsPerRun(100, set)
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, &errstr, "^validated"), t)
+	assert.Less(t, unmatched, testing.AllocsPerRun(100, set))
+	Assertnoerr(yottadb.SetValidator("validated", nil), t)
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, &errstr, "^other"), t)
+	assert.Equal(t, unmatched, testing.AllocsPerRun(100, set))
+
+	// Increments are validated with the incremented value
+	errTooBig := errors.New("too big")
+	Assertnoerr(yottadb.SetValidator("validated", func(node, value string) error {
+		if num, err := strconv.Atoi(value); nil != err || 100 < num {
+			return errTooBig
+		}
+		return nil
+	}), t)
+	Assertnoerr(yottadb.SetValE(yottadb.NOTTP, nil, "60", "^validated", []string{"a"}), t)
+	val, err = yottadb.IncrE(yottadb.NOTTP, nil, "1", "^validated", []string{"a"})
+	Assertnoerr(err, t)
+	assert.Equal(t, "61", val)
+	_, err = yottadb.IncrE(yottadb.NOTTP, nil, "50", "^validated", []string{"a"})
+	assert.Equal(t, errTooBig, err)
+	Assertnoerr(key.Varnm.SetValStr(yottadb.NOTTP, &errstr, "^validated"), t)
+	Assertnoerr(value.SetValStr(yottadb.NOTTP, &errstr, "50"), t)
+	assert.Equal(t, errTooBig, key.IncrST(yottadb.NOTTP, &errstr, &value, &value))
+	Assertnoerr(key.IncrST(yottadb.NOTTP, &errstr, nil, &value), t)
+	val, err = value.ValStr(yottadb.NOTTP, &errstr)
+	Assertnoerr(err, t)
+	assert.Equal(t, "62", val)
+	_, _, err = yottadb.IncrClampE(yottadb.NOTTP, nil, "50", "", "", "^validated", []string{"a"})
+	assert.Equal(t, errTooBig, err)
+}
@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2022 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -50,7 +50,7 @@ func (vplist *variadicPlist) alloc() {
 		// Already allocated
 		return
 	}
-	vplist.cvplist = (*C.gparam_list)(allocMem(C.size_t(C.sizeof_gparam_list)))
+	vplist.cvplist = (*C.gparam_list)(allocMem(C.size_t(C.sizeof_gparam_list), memCatParms))
 }
 
 // callVariadicPlistFunc is a variadicPlist method to drive a variadic plist function with the given "vplist".
@@ -75,7 +75,7 @@ func (vplist *variadicPlist) callVariadicPlistFunc(vpfunc unsafe.Pointer) int {
 func (vplist *variadicPlist) free() {
 	printEntry("variadicPlist.free()")
 	if (nil != vplist) && (nil != vplist.cvplist) {
-		freeMem(unsafe.Pointer(vplist.cvplist), C.size_t(C.sizeof_gparam_list))
+		freeMem(unsafe.Pointer(vplist.cvplist), C.size_t(C.sizeof_gparam_list), memCatParms)
 		vplist.cvplist = nil
 	}
 }
@@ -119,7 +119,7 @@ func (vplist *variadicPlist) setUsed(tptoken uint64, errstr *BufferT, newUsed ui
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	if C.MAX_GPARAM_LIST_ARGS < newUsed {
 		panic(fmt.Sprintf("YDB: setUsed item count %d exceeds maximum count of %d", newUsed, C.MAX_GPARAM_LIST_ARGS))
@@ -144,7 +144,7 @@ func (vplist *variadicPlist) setVPlistParam(tptoken uint64, errstr *BufferT, par
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	if C.MAX_GPARAM_LIST_ARGS <= paramindx {
 		panic(fmt.Sprintf("YDB: setVPlistParam item count %d exceeds maximum count of %d", paramindx, C.MAX_GPARAM_LIST_ARGS))
@@ -175,7 +175,7 @@ func (vplist *variadicPlist) setVPlistParam64Bit(tptoken uint64, errstr *BufferT
 		if nil != err {
 			panic(fmt.Sprintf("YDB: Error fetching STRUCTUNALLOCD: %s", err))
 		}
-		return &YDBError{(int)(YDB_ERR_STRUCTUNALLOCD), errmsg}
+		return &YDBError{errcode: (int)(YDB_ERR_STRUCTUNALLOCD), errmsg: errmsg}
 	}
 	if C.MAX_GPARAM_LIST_ARGS <= *paramindx {
 		panic(fmt.Sprintf("YDB: setVPlistParam64Bit item count %d exceeds maximum count of %d", paramindx,
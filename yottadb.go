@@ -1,6 +1,6 @@
 //////////////////////////////////////////////////////////////////
 //								//
-// Copyright (c) 2018-2024 YottaDB LLC and/or its subsidiaries.	//
+// Copyright (c) 2018-2026 YottaDB LLC and/or its subsidiaries.	//
 // All rights reserved.						//
 //								//
 //	This source code contains the intellectual property	//
@@ -108,6 +108,7 @@ const dbgSigHandling bool = false    // Print extra info when running if true
 const easyAPIDefaultDataSize uint32 = C.DEFAULT_DATA_SIZE     // Base allocation for returned data values
 const easyAPIDefaultSubscrCnt uint32 = C.DEFAULT_SUBSCR_CNT   // Base subscript count allocation for returned subscr list
 const easyAPIDefaultSubscrSize uint32 = C.DEFAULT_SUBSCR_SIZE // Base subscript size allocation for returned subscr list
+const easyAPIMaxReallocs int = 16                             // Times a buffer too small for returned data is reallocated
 
 var ydbInitialized uint32    // Atomic: Set to 1 when YDB has been initialized with a call to ydb_main_lang_init()
 var ydbSigPanicCalled uint32 // Atomic: True when our exit is panic driven due to a signal
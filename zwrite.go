@@ -0,0 +1,296 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Go-only ZWRITE format conversion functions. Unlike Str2ZwrST() and Zwr2StrST(), these do not call
+// the YottaDB engine so need no buffers, tptoken or errstr and can be used before YottaDB is initialized.
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Quote is a function to return the given string in ZWRITE format, the format YottaDB uses to display values (e.g.
+// with the ZWRITE command) and that M code can evaluate to recreate the string.
+//
+// A canonical number (see IsCanonicalNumber()) is returned as-is. Any other string is returned enclosed in double quotes
+// with embedded double quotes doubled. Characters that cannot appear literally are concatenated (with "_") as $C() for
+// ASCII control characters and as $ZCH() for bytes with the high bit set, so for example the string "a\tb" is returned as
+// "a"_$C(9)_"b". Printable non-ASCII characters in valid UTF-8 are left as-is. Consecutive escaped bytes of the same kind
+// share a single $C() or $ZCH(), e.g. $C(1,2).
+//
+// Quote() allocates only the string it returns. Callers formatting many values, such as dumps of large trees, can use
+// QuoteAppend() with a reused buffer to avoid that allocation too.
+func Quote(s string) string {
+	if IsCanonicalNumber(s) {
+		return s
+	}
+	if isPlainQuotable(s) {
+		return "\"" + s + "\""
+	}
+	return string(quoteSlow(make([]byte, 0, len(s)+16), s))
+}
+
+// QuoteAppend is a function to append the ZWRITE format of the given string, as returned by Quote(), to dst and return the
+// extended slice, as append() does. If dst has sufficient capacity, no memory is allocated.
+func QuoteAppend(dst []byte, s string) []byte {
+	if IsCanonicalNumber(s) {
+		return append(dst, s...)
+	}
+	if isPlainQuotable(s) {
+		return append(append(append(dst, '"'), s...), '"')
+	}
+	return quoteSlow(dst, s)
+}
+
+// ZwriteName is a function to return the name of the node varname(subary...) as output by ZWRITE, e.g. ^x("a",1), with
+// each subscript formatted by Quote().
+func ZwriteName(varname string, subary []string) string {
+	return string(appendZwriteName(make([]byte, 0, 64), varname, subary))
+}
+
+// appendZwriteName is a function to append the name of a node as output by ZWRITE to dst and return the extended slice.
+func appendZwriteName(dst []byte, varname string, subary []string) []byte {
+	dst = append(dst, varname...)
+	for i, sub := range subary {
+		dst = QuoteAppend(append(dst, selectString(0 == i, "(", ",")...), sub)
+	}
+	if 0 < len(subary) {
+		dst = append(dst, ')')
+	}
+	return dst
+}
+
+// Unquote is a function to convert a string in ZWRITE format, as produced by Quote() or by YottaDB itself, back to the
+// string it represents. It accepts quoted strings (with embedded double quotes doubled), canonical numbers and the
+// $C(), $CHAR(), $ZCH() and $ZCHAR() functions (with any capitalization) with one or more comma separated arguments, all
+// concatenated with "_".
+//
+// $ZCH() and $ZCHAR() arguments must be in the range 0-255 and each produces a single byte. $C() and $CHAR() arguments
+// in the range 0-255 also each produce a single byte, as they do when YottaDB runs in M mode; larger arguments (only
+// produced when YottaDB runs in UTF-8 mode) produce the UTF-8 encoding of that code point.
+//
+// If zwr is not in ZWRITE format, the INVZWRSTR error is returned.
+func Unquote(zwr string) (string, error) {
+	if IsCanonicalNumber(zwr) {
+		return zwr, nil
+	}
+	// Fast path - a single quoted string with no embedded quotes needs no copy
+	if 2 <= len(zwr) && '"' == zwr[0] && '"' == zwr[len(zwr)-1] && -1 == strings.IndexByte(zwr[1:len(zwr)-1], '"') {
+		return zwr[1 : len(zwr)-1], nil
+	}
+	var str strings.Builder
+	str.Grow(len(zwr))
+	if !unquoteSlow(&str, zwr) {
+		return "", newInvZwrStrError(zwr)
+	}
+	return str.String(), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+//
+// Utility functions
+//
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// Classes of bytes in strings converted to ZWRITE format
+const (
+	quoteLiteral = iota // Printable ASCII character other than a double quote
+	quoteDouble         // Double quote, which is doubled within quotes
+	quoteControl        // ASCII control character, escaped with $C()
+	quoteHigh           // Byte with the high bit set, left as-is in printable UTF-8 characters and otherwise escaped with $ZCH()
+)
+
+// quoteClass is the class of each byte value in strings converted to ZWRITE format
+var quoteClass = func() (class [256]uint8) {
+	for c := range class {
+		switch {
+		case '"' == c:
+			class[c] = quoteDouble
+		case ' ' <= c && '~' >= c:
+			class[c] = quoteLiteral
+		case utf8.RuneSelf <= c:
+			class[c] = quoteHigh
+		default:
+			class[c] = quoteControl
+		}
+	}
+	return class
+}()
+
+// isPlainQuotable is a function to report whether a string has only printable ASCII characters and no double quotes, the
+// commonly seen string that only needs enclosing in double quotes to be in ZWRITE format.
+func isPlainQuotable(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if quoteLiteral != quoteClass[s[i]] {
+			return false
+		}
+	}
+	return true
+}
+
+// quoteSlow is a function to append the ZWRITE format of a string that needs escaping to dst and return the extended slice.
+func quoteSlow(dst []byte, s string) []byte {
+	var fn string // Escape function currently open ("$C(" or "$ZCH(") or "" if none
+	var inQuote bool
+
+	start := len(dst)
+	for i := 0; i < len(s); {
+		c := s[i]
+		width := 1
+		class := quoteClass[c]
+		if quoteHigh == class {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if utf8.RuneError != r && unicode.IsPrint(r) {
+				class = quoteLiteral
+				width = size
+			}
+		}
+		if quoteLiteral == class || quoteDouble == class {
+			if "" != fn {
+				dst = append(dst, ')')
+				fn = ""
+			}
+			if !inQuote {
+				if start < len(dst) {
+					dst = append(dst, '_')
+				}
+				dst = append(dst, '"')
+				inQuote = true
+			}
+			if quoteDouble == class {
+				dst = append(dst, '"')
+			}
+			dst = append(dst, s[i:i+width]...)
+			i += width
+			continue
+		}
+		if inQuote {
+			dst = append(dst, '"')
+			inQuote = false
+		}
+		thisFn := selectString(quoteHigh == class, "$ZCH(", "$C(")
+		if thisFn == fn {
+			dst = append(dst, ',')
+		} else {
+			if "" != fn {
+				dst = append(dst, ')')
+			}
+			if start < len(dst) {
+				dst = append(dst, '_')
+			}
+			dst = append(dst, thisFn...)
+			fn = thisFn
+		}
+		dst = strconv.AppendInt(dst, int64(c), 10)
+		i++
+	}
+	if inQuote {
+		dst = append(dst, '"')
+	} else if "" != fn {
+		dst = append(dst, ')')
+	}
+	return dst
+}
+
+// unquoteSlow is a function to decode a ZWRITE format string into the given builder. It returns false if the string is
+// not in ZWRITE format.
+func unquoteSlow(str *strings.Builder, zwr string) bool {
+	i := 0
+	for {
+		if len(zwr) <= i {
+			return false // Empty string or trailing "_"
+		}
+		switch zwr[i] {
+		case '"':
+			// Quoted string - runs to the next double quote not followed by another double quote
+			for i++; ; i++ {
+				if len(zwr) <= i {
+					return false // Unterminated string
+				}
+				if '"' == zwr[i] {
+					if i+1 < len(zwr) && '"' == zwr[i+1] {
+						i++
+					} else {
+						break
+					}
+				}
+				str.WriteByte(zwr[i])
+			}
+			i++
+		case '$':
+			var isZch bool
+
+			// Character function - find the function name and process its arguments
+			open := strings.IndexByte(zwr[i:], '(')
+			if -1 == open {
+				return false
+			}
+			switch strings.ToUpper(zwr[i : i+open]) {
+			case "$C", "$CHAR":
+				isZch = false
+			case "$ZCH", "$ZCHAR":
+				isZch = true
+			default:
+				return false
+			}
+			i += open + 1
+			for {
+				start := i
+				for ; i < len(zwr) && '0' <= zwr[i] && '9' >= zwr[i]; i++ {
+				}
+				code, err := strconv.Atoi(zwr[start:i])
+				if nil != err || (isZch && 255 < code) || utf8.MaxRune < code {
+					return false
+				}
+				if 255 >= code {
+					str.WriteByte(byte(code))
+				} else {
+					str.WriteRune(rune(code))
+				}
+				if len(zwr) <= i {
+					return false // Unterminated function
+				}
+				if ')' == zwr[i] {
+					break
+				}
+				if ',' != zwr[i] {
+					return false
+				}
+				i++
+			}
+			i++
+		default:
+			return false
+		}
+		if len(zwr) == i {
+			return true
+		}
+		if '_' != zwr[i] {
+			return false
+		}
+		i++
+	}
+}
+
+// newInvZwrStrError is a function to create the INVZWRSTR error for the given string that is not in ZWRITE format.
+func newInvZwrStrError(zwr string) error {
+	errmsg := strings.Replace(getWrapperErrorMsg(YDB_ERR_INVZWRSTR), "!AD", zwr, 1)
+	return &YDBError{errcode: (int)(YDB_ERR_INVZWRSTR), errmsg: errmsg}
+}
@@ -0,0 +1,123 @@
+//////////////////////////////////////////////////////////////////
+//								//
+// Copyright (c) 2026 YottaDB LLC and/or its subsidiaries.	//
+// All rights reserved.						//
+//								//
+//	This source code contains the intellectual property	//
+//	of its copyright holder(s), and is made available	//
+//	under a license.  If you do not know the terms of	//
+//	the license, please stop and do not read further.	//
+//								//
+//////////////////////////////////////////////////////////////////
+
+package yottadb_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"lang.yottadb.com/go/yottadb"
+	. "lang.yottadb.com/go/yottadb/internal/test_helpers"
+	"testing"
+)
+
+// zwriteTests are pairs of strings and their ZWRITE format
+var zwriteTests = [][2]string{
+	{"", `""`},
+	{"abc", `"abc"`},
+	{"12", "12"},
+	{"-.5", "-.5"},
+	{"012", `"012"`},
+	{`say "hi"`, `"say ""hi"""`},
+	{`"`, `""""`},
+	{"a\tb", `"a"_$C(9)_"b"`},
+	{"\x01\x02", "$C(1,2)"},
+	{"\x00abc", `$C(0)_"abc"`},
+	{"abc\x7f", `"abc"_$C(127)`},
+	{"\xff\xfe", "$ZCH(255,254)"},
+	{"a\x01\xffb", `"a"_$C(1)_$ZCH(255)_"b"`},
+	{"héllo", `"héllo"`},
+	{"日本", `"日本"`},
+	{"\u0085", "$ZCH(194,133)"},
+}
+
+func TestQuote(t *testing.T) {
+	for _, test := range zwriteTests {
+		assert.Equal(t, test[1], yottadb.Quote(test[0]), "quoting %q", test[0])
+	}
+}
+
+func TestZwriteName(t *testing.T) {
+	assert.Equal(t, "^x", yottadb.ZwriteName("^x", nil))
+	assert.Equal(t, `^doc(1,"title","a"_$C(9)_"b")`, yottadb.ZwriteName("^doc", []string{"1", "title", "a\tb"}))
+}
+
+func TestQuoteAppend(t *testing.T) {
+	buf := make([]byte, 0, 256)
+	for _, test := range zwriteTests {
+		buf = append(yottadb.QuoteAppend(append(buf[:0], "x="...), test[0]), ';')
+		assert.Equal(t, "x="+test[1]+";", string(buf), "quoting %q", test[0])
+	}
+	assert.Equal(t, 256, cap(buf)) // Appended in place
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, test := range zwriteTests {
+			buf = yottadb.QuoteAppend(buf[:0], test[0])
+		}
+	})
+	assert.Equal(t, float64(0), allocs)
+}
+
+func TestUnquote(t *testing.T) {
+	for _, test := range zwriteTests {
+		str, err := yottadb.Unquote(test[1])
+		Assertnoerr(err, t)
+		assert.Equal(t, test[0], str, "unquoting %q", test[1])
+	}
+	// Alternate function names and code points above 255
+	alternates := [][2]string{
+		{`$char(65,66)_$zchar(67)_$Zch(68)`, "ABCD"},
+		{"$C(233)", "\xe9"},
+		{"$C(8364)", "€"},
+		{`"a"_"b"`, "ab"},
+	}
+	for _, alt := range alternates {
+		str, err := yottadb.Unquote(alt[0])
+		Assertnoerr(err, t)
+		assert.Equal(t, alt[1], str, "unquoting %q", alt[0])
+	}
+	invalid := []string{"", "abc", `"abc`, `"a""`, `"a"_`, `"a"b`, `$C(`, `$C(1`, `$C(1,)`, `$C()`, `$X(1)`,
+		`$ZCH(256)`, `$C(1114112)`, `$C(a)`, "1.50", `_"a"`}
+	for _, zwr := range invalid {
+		_, err := yottadb.Unquote(zwr)
+		assert.Equal(t, yottadb.YDB_ERR_INVZWRSTR, yottadb.ErrorCode(err), "unquoting %q", zwr)
+	}
+}
+
+func TestQuoteMatchesEngine(t *testing.T) {
+	var str, zwr yottadb.BufferT
+
+	str.Alloc(64)
+	defer str.Free()
+	zwr.Alloc(512)
+	defer zwr.Free()
+	// The engine must be able to decode everything Quote() produces
+	for _, test := range zwriteTests {
+		err := zwr.SetValStr(yottadb.NOTTP, nil, yottadb.Quote(test[0]))
+		Assertnoerr(err, t)
+		err = zwr.Zwr2StrST(yottadb.NOTTP, nil, &str)
+		Assertnoerr(err, t)
+		val, err := str.ValStr(yottadb.NOTTP, nil)
+		Assertnoerr(err, t)
+		assert.Equal(t, test[0], val)
+	}
+	// And Unquote() must be able to decode everything the engine produces
+	for _, test := range zwriteTests {
+		err := str.SetValStr(yottadb.NOTTP, nil, test[0])
+		Assertnoerr(err, t)
+		err = str.Str2ZwrST(yottadb.NOTTP, nil, &zwr)
+		Assertnoerr(err, t)
+		val, err := zwr.ValStr(yottadb.NOTTP, nil)
+		Assertnoerr(err, t)
+		unquoted, err := yottadb.Unquote(val)
+		Assertnoerr(err, t)
+		assert.Equal(t, test[0], unquoted)
+	}
+}